@@ -0,0 +1,96 @@
+package iterator
+
+import "time"
+
+// Batch returns an iterator over slices ([]interface{}) of up to size consecutive elements of the original.
+// Only the last batch can be smaller. An error is reported after the batch of the elements preceding it.
+func Batch(it Iterator, size int) Iterator {
+	if size < 1 {
+		panic("iterator: batch size must be positive")
+	}
+	var failure error
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if failure != nil {
+				return nil, true, failure
+			}
+			var batch []interface{}
+			for len(batch) < size {
+				next, ok, err := pull(it)
+				if err != nil {
+					failure = err
+					break
+				}
+				if !ok {
+					break
+				}
+				batch = append(batch, next)
+			}
+			return batchOrEnd(batch, failure)
+		},
+		closer: func() error {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
+
+// BatchBy is like Batch but doesn't wait more than maxWait, from the arrival of the first element of a batch,
+// for the batch to fill up: whatever arrived by then is returned as a partial batch.
+// The original iterator is drained on a background goroutine; closing the iterator stops it, waiting for an
+// in-flight call to the original to return, then closes the original.
+func BatchBy(it Iterator, size int, maxWait time.Duration) Iterator {
+	if size < 1 {
+		panic("iterator: batch size must be positive")
+	}
+	var pumped *pumping
+	var failure error
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if pumped == nil {
+				pumped = startPumping(it, 0)
+			}
+			if failure != nil {
+				return nil, true, failure
+			}
+
+			var batch []interface{}
+			var timeout <-chan time.Time
+			for len(batch) < size {
+				select {
+				case r, ok := <-pumped.out:
+					if !ok {
+						return batchOrEnd(batch, nil)
+					}
+					if r.err != nil {
+						failure = r.err
+						return batchOrEnd(batch, failure)
+					}
+					batch = append(batch, r.item)
+					if timeout == nil {
+						timer := time.NewTimer(maxWait)
+						defer timer.Stop()
+						timeout = timer.C
+					}
+				case <-timeout:
+					return batch, false, nil
+				}
+			}
+			return batch, false, nil
+		},
+		closer: func() error {
+			if pumped != nil {
+				pumped.stop()
+			}
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
+
+func batchOrEnd(batch []interface{}, err error) (interface{}, bool, error) {
+	if len(batch) == 0 {
+		return nil, true, err
+	}
+	return batch, false, nil
+}
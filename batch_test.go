@@ -0,0 +1,77 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func batchIds(t *testing.T, iterator Iterator) [][]int {
+	var batches [][]int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		batches = append(batches, ids(item.([]interface{})...))
+	}
+	return batches
+}
+
+func TestBatch(t *testing.T) {
+	iterator := Batch(Items(generateItems(0, 7)).Iterator(), 3)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, batchIds(t, iterator))
+	assert.Nil(t, iterator.Err())
+	assert.Nil(t, iterator.Close())
+}
+
+func TestBatch_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Batch(failingIterator(generateItems(0, 4), failure), 3)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3}}, batchIds(t, iterator))
+	assert.Equal(t, failure, iterator.Err())
+}
+
+func TestBatchBy(t *testing.T) {
+	items := generateItems(0, 7)
+	computeNext := next(items)
+	iterator := BatchBy(NewDefaultIterator(func() (interface{}, bool, error) {
+		next, eod, err := computeNext()
+		// a slow source, stalling after the first two elements
+		if !eod && next.(*Item).ID == 2 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		return next, eod, err
+	}), 4, 20*time.Millisecond)
+
+	assert.Equal(t, [][]int{{0, 1}, {2, 3, 4, 5}, {6}}, batchIds(t, iterator))
+	assert.Nil(t, iterator.Err())
+	assert.Nil(t, iterator.Close())
+}
+
+func TestBatchBy_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := BatchBy(failingIterator(generateItems(0, 4), failure), 3, time.Second)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3}}, batchIds(t, iterator))
+	assert.Equal(t, failure, iterator.Err())
+	assert.Nil(t, iterator.Close())
+}
+
+func TestBatchBy_CloseEarly(t *testing.T) {
+	closed := false
+	index := 0
+	source := NewCloseableIterator(func() (interface{}, bool, error) {
+		index++
+		return index, false, nil
+	}, func() error {
+		closed = true
+		return nil
+	})
+
+	iterator := BatchBy(source, 2, time.Second)
+	batch, err := iterator.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, batch)
+	assert.Nil(t, iterator.Close())
+	assert.True(t, closed)
+}
@@ -0,0 +1,81 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+)
+
+// FromChannel returns an iterator over the values received from the channel, ending when the channel is closed.
+// Waiting for a value stops as soon as the context bound to the iterator (see WithContext) is done.
+func FromChannel[T any](ch <-chan T) Iterator {
+	return FromChannelWithErrors[T](ch, nil)
+}
+
+// FromChannelWithErrors is like FromChannel, failing as soon as a non nil error is received from errc.
+// A producer reporting an error when it is over must send it before closing ch.
+func FromChannelWithErrors[T any](ch <-chan T, errc <-chan error) Iterator {
+	return NewDefaultIteratorCtx(func(ctx context.Context) (interface{}, bool, error) {
+		for {
+			select {
+			case next, ok := <-ch:
+				if ok {
+					return next, false, nil
+				}
+				// an error sent before closing ch may not have been received yet
+				select {
+				case err := <-errc:
+					return nil, true, err
+				default:
+					return nil, true, nil
+				}
+			case err, ok := <-errc:
+				if err != nil {
+					return nil, false, err
+				}
+				if !ok {
+					errc = nil
+				}
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			}
+		}
+	})
+}
+
+// ToChannel drains the iterator on a new goroutine, sending its elements to the returned channel, closed at the end.
+// The error that stopped the iteration, if any, is sent to the error channel before closing the elements channel,
+// the error channel being closed after it. The iteration stops when the context is done, reporting ctx.Err().
+// The context is bound to the iterator (see WithContext), keeping the context already bound to it if any,
+// and the iterator is closed at the end of the iteration.
+func ToChannel(ctx context.Context, it Iterator) (<-chan interface{}, <-chan error) {
+	out := make(chan interface{})
+	errc := make(chan error, 1)
+	bindContext(ctx, it)
+	go func() {
+		defer close(errc)
+		err := func() error {
+			for {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				next, ok, err := pull(it)
+				if err != nil || !ok {
+					return err
+				}
+				select {
+				case out <- next:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}()
+		if closeErr := it.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+		if err != nil {
+			errc <- err
+		}
+		close(out)
+	}()
+	return out, errc
+}
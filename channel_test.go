@@ -0,0 +1,116 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChannel(t *testing.T) {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+	}()
+
+	items, err := Collect(FromChannel(ch))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, items)
+}
+
+func TestFromChannel_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// nothing is ever sent
+	_, err := Collect(WithContext(ctx, FromChannel(make(chan int))))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestFromChannelWithErrors(t *testing.T) {
+	failure := errors.New("failure")
+	ch := make(chan string)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		ch <- "a"
+		ch <- "b"
+		errc <- failure
+	}()
+
+	items, err := Collect(FromChannelWithErrors(ch, errc))
+	assert.Equal(t, []interface{}{"a", "b"}, items)
+	assert.Equal(t, failure, err)
+
+	// a closed error channel without errors
+	ch = make(chan string, 1)
+	errc = make(chan error)
+	ch <- "a"
+	close(ch)
+	close(errc)
+	items, err = Collect(FromChannelWithErrors(ch, errc))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a"}, items)
+}
+
+func TestToChannel(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 10))
+	out, errc := ToChannel(context.Background(), source)
+
+	var items []interface{}
+	for item := range out {
+		items = append(items, item)
+	}
+	assert.Nil(t, <-errc)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ids(items...))
+	assert.True(t, *closed)
+}
+
+func TestToChannel_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	out, errc := ToChannel(context.Background(), failingIterator(generateItems(0, 3), failure))
+
+	count := 0
+	for range out {
+		count++
+	}
+	assert.Equal(t, 3, count)
+	assert.Equal(t, failure, <-errc)
+}
+
+func TestToChannel_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	closed := make(chan struct{})
+	source := NewCloseableIterator(next(generateItems(0, 10)), func() error {
+		close(closed)
+		return nil
+	})
+	out, errc := ToChannel(ctx, source)
+
+	<-out
+	cancel()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("the iterator wasn't closed")
+	}
+	for range out {
+	}
+	assert.Equal(t, context.Canceled, <-errc)
+}
+
+func TestToChannel_KeepsContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// the deadline bound to the iterator still applies
+	out, errc := ToChannel(context.Background(), WithContext(ctx, FromChannel(make(chan int))))
+	for range out {
+	}
+	assert.Equal(t, context.DeadlineExceeded, <-errc)
+}
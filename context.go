@@ -0,0 +1,108 @@
+package iterator
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithContext binds the context to the iterator and to every iterator it is built on, so that a chain of
+// combinators over a ComputeNextCtx source hands the context down to it. An iterator already bound to a context
+// keeps it: it is bound to a context done as soon as either of them is.
+// Once the context is cancelled or its deadline expires, HasNext returns false and Next returns ctx.Err().
+func WithContext(ctx context.Context, it Iterator) Iterator {
+	bindContext(ctx, it)
+	return &DefaultIterator{
+		ctx: ctx,
+		ComputeNext: func() (interface{}, bool, error) {
+			if !it.HasNext() {
+				if err := ctx.Err(); err != nil {
+					return nil, false, err
+				}
+				return nil, true, it.Err()
+			}
+			next, err := it.Next()
+			if err != nil {
+				return nil, false, err
+			}
+			return next, false, nil
+		},
+		closer:  it.Close,
+		sources: []Iterator{it},
+	}
+}
+
+// Implemented by the iterators able to carry a context.
+type contextBinder interface {
+	bindContext(ctx context.Context)
+}
+
+func bindContext(ctx context.Context, iterators ...Iterator) {
+	for _, it := range iterators {
+		if binder, ok := it.(contextBinder); ok {
+			binder.bindContext(ctx)
+		}
+	}
+}
+
+// A context done as soon as either of its parents is, with the earliest deadline of the two.
+// Values are looked up in the first parent, then in the second one.
+type mergedContext struct {
+	first, second context.Context
+	done          chan struct{}
+
+	mu    sync.Mutex
+	err   error
+	stops [2]func() bool
+}
+
+func mergeContexts(first, second context.Context) context.Context {
+	c := &mergedContext{first: first, second: second, done: make(chan struct{})}
+	cancel := func(parent context.Context) func() {
+		return func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.err != nil {
+				return
+			}
+			c.err = parent.Err()
+			close(c.done)
+			// stops waiting for the other parent
+			for _, stop := range c.stops {
+				stop()
+			}
+		}
+	}
+	c.mu.Lock()
+	c.stops = [2]func() bool{
+		context.AfterFunc(first, cancel(first)),
+		context.AfterFunc(second, cancel(second)),
+	}
+	c.mu.Unlock()
+	return c
+}
+
+func (c *mergedContext) Deadline() (time.Time, bool) {
+	deadline, ok := c.first.Deadline()
+	if other, otherOk := c.second.Deadline(); otherOk && (!ok || other.Before(deadline)) {
+		return other, true
+	}
+	return deadline, ok
+}
+
+func (c *mergedContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *mergedContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *mergedContext) Value(key interface{}) interface{} {
+	if value := c.first.Value(key); value != nil {
+		return value
+	}
+	return c.second.Value(key)
+}
@@ -0,0 +1,139 @@
+package iterator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestWithContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iterator := WithContext(ctx, Items(generateItems(0, 10)).Iterator())
+
+	assert.True(t, iterator.HasNext())
+	_, err := iterator.Next()
+	assert.Nil(t, err)
+
+	cancel()
+	assert.False(t, iterator.HasNext())
+	_, err = iterator.Next()
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestWithContext_CancelledWhenReady(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iterator := WithContext(ctx, Items(generateItems(0, 10)).Iterator())
+
+	assert.True(t, iterator.HasNext())
+	cancel()
+	_, err := iterator.Next()
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestWithContext_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	source := NewDefaultIteratorCtx(func(ctx context.Context) (interface{}, bool, error) {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(time.Second):
+			return 1, false, nil
+		}
+	})
+	iterator := WithContext(ctx, source)
+
+	_, err := iterator.Next()
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWithContext_PropagatesDownTheChain(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	items := generateItems(0, 10)
+	computeNext := next(items)
+	var seen []interface{}
+	source := NewCloseableIteratorCtx(func(ctx context.Context) (interface{}, bool, error) {
+		seen = append(seen, ctx.Value(ctxKey{}))
+		return computeNext()
+	}, func() error {
+		return nil
+	})
+
+	iterator := Limit(Skip(Filter(source, func(item interface{}) (bool, error) {
+		return true, nil
+	}), 2), 3)
+	iterator = WithContext(ctx, Concat(iterator))
+
+	i := 0
+	for iterator.HasNext() {
+		_, err := iterator.Next()
+		assert.Nil(t, err)
+		i++
+	}
+	assert.Equal(t, 3, i)
+	assert.Len(t, seen, 5)
+	for _, v := range seen {
+		assert.Equal(t, "value", v)
+	}
+}
+
+func TestComputeNextCtx_DefaultsToBackground(t *testing.T) {
+	iterator := NewDefaultIteratorCtx(func(ctx context.Context) (interface{}, bool, error) {
+		assert.Equal(t, context.Background(), ctx)
+		return nil, true, nil
+	})
+	assert.False(t, iterator.HasNext())
+}
+
+// A source waiting for its context to be done
+func blocking() Iterator {
+	return NewDefaultIteratorCtx(func(ctx context.Context) (interface{}, bool, error) {
+		<-ctx.Done()
+		return nil, false, ctx.Err()
+	})
+}
+
+func TestWithContext_Nested(t *testing.T) {
+	identity := func(item interface{}) (interface{}, error) {
+		return item, nil
+	}
+
+	// the inner deadline is kept by the outer context
+	inner, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	iterator := WithContext(context.Background(), Transform(WithContext(inner, blocking()), identity))
+	_, err := iterator.Next()
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// and the outer context still reaches the source
+	outer, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	iterator = WithContext(outer, Transform(WithContext(context.Background(), blocking()), identity))
+	_, err = iterator.Next()
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestMergeContexts(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "first"))
+	deadline := time.Now().Add(time.Hour)
+	second, cancelSecond := context.WithDeadline(context.Background(), deadline)
+	defer cancelSecond()
+
+	merged := mergeContexts(first, second)
+	assert.Equal(t, "first", merged.Value(ctxKey{}))
+	d, ok := merged.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, deadline, d)
+	assert.Nil(t, merged.Err())
+
+	cancelFirst()
+	<-merged.Done()
+	assert.Equal(t, context.Canceled, merged.Err())
+}
@@ -0,0 +1,96 @@
+package iterator
+
+import "strconv"
+
+// ChangeKind is the kind of difference reported by Diff.
+type ChangeKind int
+
+const (
+	// The element is only found in the new iterator
+	Added ChangeKind = iota
+	// The element is only found in the old iterator
+	Removed
+	// Both iterators have the element, with different content
+	Changed
+	// Both iterators have the element, with the same content
+	Unchanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	case Changed:
+		return "Changed"
+	case Unchanged:
+		return "Unchanged"
+	}
+	return "ChangeKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Change is a difference between two iterators. Old is nil for Added elements, New is nil for Removed ones.
+type Change struct {
+	Kind ChangeKind
+	Old  interface{}
+	New  interface{}
+}
+
+// Diff walks two iterators sorted by key, the old and new versions of the same data, returning the changes (Change)
+// turning the old one into the new one. The compare function compares the keys of two elements, the equals function
+// their content. Elements without changes are not returned, see DiffAll.
+func Diff(before, after Iterator, compareFn CompareFunc, equalsFn EqualsFunc) Iterator {
+	return diff(before, after, compareFn, equalsFn, false)
+}
+
+// DiffAll is like Diff but also returns the Unchanged elements.
+func DiffAll(before, after Iterator, compareFn CompareFunc, equalsFn EqualsFunc) Iterator {
+	return diff(before, after, compareFn, equalsFn, true)
+}
+
+func diff(before, after Iterator, compareFn CompareFunc, equalsFn EqualsFunc, unchanged bool) Iterator {
+	o, n := &lookahead{it: before}, &lookahead{it: after}
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			for {
+				okO, err := o.head()
+				if err != nil {
+					return nil, true, err
+				}
+				okN, err := n.head()
+				if err != nil {
+					return nil, true, err
+				}
+
+				switch {
+				case !okO && !okN:
+					return nil, true, nil
+				case !okO:
+					return Change{Kind: Added, New: n.take()}, false, nil
+				case !okN:
+					return Change{Kind: Removed, Old: o.take()}, false, nil
+				}
+
+				c := compareFn(o.item, n.item)
+				if c < 0 {
+					return Change{Kind: Removed, Old: o.take()}, false, nil
+				}
+				if c > 0 {
+					return Change{Kind: Added, New: n.take()}, false, nil
+				}
+				oldItem, newItem := o.take(), n.take()
+				if !equalsFn(oldItem, newItem) {
+					return Change{Kind: Changed, Old: oldItem, New: newItem}, false, nil
+				}
+				if unchanged {
+					return Change{Kind: Unchanged, Old: oldItem, New: newItem}, false, nil
+				}
+			}
+		},
+		closer: func() error {
+			return closeAll([]Iterator{before, after})
+		},
+		sources: []Iterator{before, after},
+	}
+}
@@ -0,0 +1,55 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sameName(item1 interface{}, item2 interface{}) bool {
+	return item1.(*Item).Name == item2.(*Item).Name
+}
+
+func changes(t *testing.T, iterator Iterator) []string {
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	var result []string
+	for _, item := range items {
+		change := item.(Change)
+		switch change.Kind {
+		case Added:
+			result = append(result, fmt.Sprintf("%s %d", change.Kind, change.New.(*Item).ID))
+		case Removed:
+			result = append(result, fmt.Sprintf("%s %d", change.Kind, change.Old.(*Item).ID))
+		default:
+			result = append(result, fmt.Sprintf("%s %d %s->%s", change.Kind, change.Old.(*Item).ID,
+				change.Old.(*Item).Name, change.New.(*Item).Name))
+		}
+	}
+	return result
+}
+
+func TestDiff(t *testing.T) {
+	before := Items{{1, "a"}, {2, "b"}, {3, "c"}, {5, "e"}}
+	after := Items{{0, "z"}, {2, "b"}, {3, "C"}, {4, "d"}, {5, "e"}, {6, "f"}}
+
+	assert.Equal(t, []string{"Added 0", "Removed 1", "Changed 3 c->C", "Added 4", "Added 6"},
+		changes(t, Diff(before.Iterator(), after.Iterator(), compareIds, sameName)))
+	assert.Equal(t, []string{"Added 0", "Removed 1", "Unchanged 2 b->b", "Changed 3 c->C", "Added 4", "Unchanged 5 e->e", "Added 6"},
+		changes(t, DiffAll(before.Iterator(), after.Iterator(), compareIds, sameName)))
+}
+
+func TestDiff_Empty(t *testing.T) {
+	assert.Equal(t, []string{"Removed 1", "Removed 2"},
+		changes(t, Diff(Items(itemsFromIds(1, 2)).Iterator(), Items(nil).Iterator(), compareIds, sameName)))
+	assert.Nil(t, changes(t, Diff(Items(nil).Iterator(), Items(nil).Iterator(), compareIds, sameName)))
+}
+
+func TestDiff_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	_, err := Collect(Diff(Items(itemsFromIds(1, 2)).Iterator(), failingIterator(itemsFromIds(1), failure), compareIds, sameName))
+	assert.Equal(t, failure, err)
+	assert.Equal(t, "Changed", Changed.String())
+}
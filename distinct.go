@@ -0,0 +1,105 @@
+package iterator
+
+import (
+	"hash/maphash"
+	"math"
+)
+
+// KeySet records the keys of the elements returned by Distinct.
+type KeySet interface {
+	// Add records the key, returning false if it was already recorded.
+	Add(key interface{}) bool
+}
+
+// Distinct creates a wrapper-iterator over the original that will return only the first element for every key,
+// wherever duplicates are in the iteration. Every key is kept in memory, see DistinctWith to bound it.
+func Distinct(it Iterator, keyFn KeyFunc) Iterator {
+	return DistinctWith(it, keyFn, NewHashKeySet())
+}
+
+// DistinctWith is like Distinct but records the keys in the given set.
+func DistinctWith(it Iterator, keyFn KeyFunc, keys KeySet) Iterator {
+	index := 0
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			for {
+				next, ok, err := pull(it)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				key, err := keyFn(next)
+				if err != nil {
+					return nil, false, &StageError{Op: "Distinct", Index: index, Err: err}
+				}
+				index++
+				if keys.Add(key) {
+					return next, false, nil
+				}
+			}
+		},
+		closer: func() error {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
+
+type hashKeySet map[interface{}]struct{}
+
+// NewHashKeySet returns an exact KeySet, holding every key in memory.
+func NewHashKeySet() KeySet {
+	return hashKeySet{}
+}
+
+func (s hashKeySet) Add(key interface{}) bool {
+	if _, ok := s[key]; ok {
+		return false
+	}
+	s[key] = struct{}{}
+	return true
+}
+
+// A Bloom filter, see https://en.wikipedia.org/wiki/Bloom_filter
+type bloomKeySet struct {
+	bits  []uint64
+	m     uint64
+	k     int
+	seeds [2]maphash.Seed
+}
+
+// NewBloomKeySet returns a KeySet of bounded memory, sized for the expected number of keys, backed by a Bloom filter.
+// A key is wrongly reported as recorded with probability falsePositiveRate, as long as no more than expected keys
+// are added, so Distinct drops that fraction of the distinct elements.
+func NewBloomKeySet(expected int, falsePositiveRate float64) KeySet {
+	if expected < 1 {
+		expected = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("iterator: false positive rate must be between 0 and 1")
+	}
+	// optimal number of bits and of hash functions
+	m := uint64(math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	k := int(math.Max(1, math.Round(float64(m)/float64(expected)*math.Ln2)))
+	return &bloomKeySet{
+		bits:  make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		seeds: [2]maphash.Seed{maphash.MakeSeed(), maphash.MakeSeed()},
+	}
+}
+
+func (s *bloomKeySet) Add(key interface{}) bool {
+	// double hashing, deriving the k positions from two hashes
+	h1 := maphash.Comparable(s.seeds[0], key)
+	h2 := maphash.Comparable(s.seeds[1], key) | 1
+	added := false
+	for i := 0; i < s.k; i++ {
+		bit := (h1 + uint64(i)*h2) % s.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bits[word]&mask == 0 {
+			s.bits[word] |= mask
+			added = true
+		}
+	}
+	return added
+}
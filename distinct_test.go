@@ -0,0 +1,60 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func id(item interface{}) (interface{}, error) {
+	return item.(*Item).ID, nil
+}
+
+func TestDistinct(t *testing.T) {
+	iterator := Distinct(Items(itemsFromIds(3, 1, 3, 2, 1, 4, 2)).Iterator(), id)
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 1, 2, 4}, ids(items...))
+}
+
+func TestDistinct_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	items, err := Collect(Distinct(failingIterator(itemsFromIds(1, 1, 2), failure), id))
+	assert.Equal(t, []int{1, 2}, ids(items...))
+	assert.Equal(t, failure, err)
+
+	_, err = Collect(Distinct(Items(itemsFromIds(1)).Iterator(), func(item interface{}) (interface{}, error) {
+		return nil, failure
+	}))
+	assert.True(t, errors.Is(err, failure))
+}
+
+func TestBloomKeySet(t *testing.T) {
+	const expected = 10000
+	keys := NewBloomKeySet(expected, 0.01)
+	for i := 0; i < expected; i++ {
+		keys.Add(i)
+	}
+	// no false negatives
+	for i := 0; i < expected; i++ {
+		assert.False(t, keys.Add(i))
+	}
+	// probes a few new keys, each one slightly filling the set further
+	const probes = 1000
+	falsePositives := 0
+	for i := expected; i < expected+probes; i++ {
+		if !keys.Add(i) {
+			falsePositives++
+		}
+	}
+	// within the expected rate, leaving room for randomness
+	assert.True(t, falsePositives < probes*5/100, "false positives: %d", falsePositives)
+}
+
+func TestDistinctWith(t *testing.T) {
+	iterator := DistinctWith(Items(itemsFromIds(3, 1, 3, 2, 1)).Iterator(), id, NewBloomKeySet(100, 0.001))
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 1, 2}, ids(items...))
+}
@@ -0,0 +1,45 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// Returned by Next and Peek when the iteration has no more elements.
+	ErrNoSuchElement = errors.New("iterator: no such element")
+	// Returned by Next and Peek once the iterator has been closed.
+	ErrClosed = errors.New("iterator: closed")
+	// Returned when an element can't be buffered without exceeding the configured bound.
+	ErrBufferFull = errors.New("iterator: buffer full")
+)
+
+// StageError reports a failure of the function given to an operator, e.g. the predicate of a Filter,
+// identifying the operator and the index of the element it was processing.
+// Errors coming from upstream iterators are passed along unwrapped, so the innermost failing stage is reported.
+type StageError struct {
+	// Name of the operator, e.g. "Filter"
+	Op string
+	// Zero based index of the element, counted from the start of the operator's input
+	Index int
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("iterator: %s: element %d: %v", e.Op, e.Index, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// closeAll closes every iterator, joining the errors encountered.
+func closeAll(iterators []Iterator) error {
+	var errs []error
+	for _, it := range iterators {
+		if err := it.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
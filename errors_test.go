@@ -0,0 +1,83 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrNoSuchElement(t *testing.T) {
+	iterator := Items(nil).Iterator()
+	assert.False(t, iterator.HasNext())
+
+	_, err := iterator.Next()
+	assert.Equal(t, ErrNoSuchElement, err)
+	_, err = iterator.Peek()
+	assert.Equal(t, ErrNoSuchElement, err)
+	assert.Nil(t, iterator.Err())
+}
+
+func TestErrClosed(t *testing.T) {
+	closes := 0
+	iterator := NewCloseableIterator(next(generateItems(0, 10)), func() error {
+		closes++
+		return nil
+	})
+	assert.True(t, iterator.HasNext())
+	assert.Nil(t, iterator.Close())
+	assert.Nil(t, iterator.Close())
+	assert.Equal(t, 1, closes)
+
+	assert.False(t, iterator.HasNext())
+	_, err := iterator.Next()
+	assert.Equal(t, ErrClosed, err)
+	_, err = iterator.Peek()
+	assert.Equal(t, ErrClosed, err)
+}
+
+func TestStageError(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Items(generateItems(0, 10)).Iterator()
+	iterator = Filter(iterator, func(item interface{}) (bool, error) {
+		return item.(*Item).ID%2 == 0, nil
+	})
+	iterator = Transform(iterator, func(item interface{}) (interface{}, error) {
+		if item.(*Item).ID == 6 {
+			return nil, failure
+		}
+		return item, nil
+	})
+	iterator = Filter(iterator, func(item interface{}) (bool, error) {
+		return true, nil
+	})
+
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+	}
+	err := iterator.Err()
+	assert.True(t, errors.Is(err, failure))
+
+	var stageErr *StageError
+	assert.True(t, errors.As(err, &stageErr))
+	assert.Equal(t, "Transform", stageErr.Op)
+	assert.Equal(t, 3, stageErr.Index)
+	assert.Equal(t, "iterator: Transform: element 3: failure", err.Error())
+}
+
+func TestCloseErrors(t *testing.T) {
+	failure1 := errors.New("failure 1")
+	failure2 := errors.New("failure 2")
+	failing := func(err error) Iterator {
+		return NewCloseableIterator(next(nil), func() error {
+			return err
+		})
+	}
+
+	err := Concat(failing(failure1), Items(nil).Iterator(), failing(failure2)).Close()
+	assert.True(t, errors.Is(err, failure1))
+	assert.True(t, errors.Is(err, failure2))
+
+	err = Merge(func(item1 interface{}, item2 interface{}) int { return 0 }, failing(failure1)).Close()
+	assert.True(t, errors.Is(err, failure1))
+}
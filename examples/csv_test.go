@@ -21,7 +21,7 @@ const csvRows = `first_name,last_name,username
 Ken,Thompson,ken
 "Robert","Griesemer","gri"
 `
-func ExampleCsv() {
+func Example_csv() {
 	iter, err := NewCsvIterator()
 	if err != nil {
 		log.Printf("error opening file")
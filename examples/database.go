@@ -1,6 +1,7 @@
 package example
 
 import (
+	"context"
 	"database/sql"
 	
 	
@@ -12,6 +13,7 @@ import (
 // A typical database access usage where it executes a query that returns rows.
 // Then we take advantage of the iterator creating the `next` function, which in the sql package
 // fits quite well given that `Rows` implements the same concept, calling `Next()` before it call `Scan`.
+// The iterator is bound to the caller context, so the iteration stops as soon as the caller goes away.
 
 type address struct {
 	number int
@@ -22,9 +24,9 @@ const selectQueryStmt = `SELECT address.street_number, address.street FROM addre
 							JOIN user_addresses ON address.id=user_addresses.address_id
 							WHERE user_addresses.user_id = $1;`
 
-func LoadUserAddresses(db *sql.DB, userID int64) error {
+func LoadUserAddresses(ctx context.Context, db *sql.DB, userID int64) error {
 	// runs the query
-	rows, err := db.Query(selectQueryStmt, userID)
+	rows, err := db.QueryContext(ctx, selectQueryStmt, userID)
 	if err != nil {
 		return err
 	}
@@ -49,17 +51,18 @@ func LoadUserAddresses(db *sql.DB, userID int64) error {
 		return nil
 	}
 	
-	iter := iterator.NewCloseableIterator(nextFn, closeFn)
+	iter := iterator.WithContext(ctx, iterator.NewCloseableIterator(nextFn, closeFn))
 	defer iter.Close()
 	
 	for  iter.HasNext() {
 		
 		elem, err := iter.Next()
 		if err != nil {
-			break
+			return err
 		}
 		fmt.Println(elem)
 	}
 	
-	return nil
+	// reports a failed query or a cancelled context
+	return iter.Err()
 }
@@ -6,9 +6,10 @@ import (
 	"github.com/pkg/errors"
 )
 
-// This example shows how to use an iterator to implement the unix tee pipe.
+// This example shows how to use an iterator to implement the unix tee pipe,
+// splitting one iterator into two independent ones.
 
-func ExampleTee() {
+func Example_tee() {
 	
 	// transform function
 	var tr iterator.TransformFunc = func(item interface{}) (interface{}, error) {
@@ -16,56 +17,38 @@ func ExampleTee() {
 		if !ok {
 			return nil, errors.New("failed casting item to type *MyItem")
 		}
-		i.Name = i.Name + "Tr"
-		return i, nil
+		return fmt.Sprintf("%sTr", i.Name), nil
 	}
 	
 	// slice of ints with an iterator
-	items := itemsArray(1, 10)
+	items := itemsArray(1, 5)
 	sliceIter := MyItemArray(items).Iterator()
 	
-	// the tee iterator
-	iter := TeeIterator(sliceIter, tr)
+	// the tee branches, one of them transformed
+	branches := iterator.Tee(sliceIter, 2)
+	original, transformed := branches[0], iterator.Transform(branches[1], tr)
 	
-	// iterate over the transformed values
-	for iter.HasNext() {
-		trItem, err := iter.Next()
+	// iterate over both branches
+	for original.HasNext() && transformed.HasNext() {
+		item, err := original.Next()
+		if err != nil {
+			return
+		}
+		trItem, err := transformed.Next()
 		if err != nil {
 			return
 		}
 		
-		fmt.Printf("%+v\n", trItem)
+		fmt.Printf("%+v %s\n", item, trItem)
 	}
+	original.Close()
+	transformed.Close()
 	// Output:
-	// &{Id:1 Name:item_0001Tr}
-	// &{Id:2 Name:item_0002Tr}
-	// &{Id:3 Name:item_0003Tr}
-	// &{Id:4 Name:item_0004Tr}
-	// &{Id:5 Name:item_0005Tr}
-	// &{Id:6 Name:item_0006Tr}
-	// &{Id:7 Name:item_0007Tr}
-	// &{Id:8 Name:item_0008Tr}
-	// &{Id:9 Name:item_0009Tr}
-	// &{Id:10 Name:item_0010Tr}
-}
-
-
-type teeIterator struct {
-	iterator.Iterator
-	iterator.TransformFunc
-}
-
-func TeeIterator(iterator iterator.Iterator, fn iterator.TransformFunc) iterator.Iterator {
-	return &teeIterator{ iterator, fn }
-}
-
-func (t *teeIterator) Next() (next interface{}, e error) {
-	n, err := t.Iterator.Next()
-	if  err != nil {
-		return nil, err
-	}
-	
-	return t.TransformFunc(n)
+	// &{Id:1 Name:item_0001} item_0001Tr
+	// &{Id:2 Name:item_0002} item_0002Tr
+	// &{Id:3 Name:item_0003} item_0003Tr
+	// &{Id:4 Name:item_0004} item_0004Tr
+	// &{Id:5 Name:item_0005} item_0005Tr
 }
 
 // Helpers
@@ -0,0 +1,69 @@
+package iterator
+
+import (
+	"context"
+	"sync"
+)
+
+// FanIn combines multiple iterators into a single one returning the elements in the order they arrive:
+// every iterator is drained on its own goroutine, so that slow iterators are read concurrently.
+// The first error returned by any of the iterators fails the combined one, cancelling the context bound to
+// the others (see WithContext) and closing them, after waiting for the in-flight calls to return.
+// Closing the iterator does the same.
+func FanIn(iterators ...Iterator) Iterator {
+	var results chan result
+	var wg sync.WaitGroup
+	var cancel context.CancelFunc
+	var closeErr error
+	done := make(chan struct{})
+	stopped := false
+	stop := func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		close(done)
+		if cancel != nil {
+			cancel()
+		}
+		wg.Wait()
+		closeErr = closeAll(iterators)
+	}
+
+	fanIn := &DefaultIterator{
+		sources: iterators,
+	}
+	fanIn.ComputeNext = func() (interface{}, bool, error) {
+		if results == nil {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(fanIn.context())
+			bindContext(ctx, iterators...)
+			results = make(chan result)
+			for _, it := range iterators {
+				wg.Add(1)
+				go func(it Iterator) {
+					defer wg.Done()
+					pump(it, results, done)
+				}(it)
+			}
+			go func() {
+				wg.Wait()
+				close(results)
+			}()
+		}
+		r, ok := <-results
+		if !ok {
+			return nil, true, nil
+		}
+		if r.err != nil {
+			stop()
+			return nil, false, r.err
+		}
+		return r.item, false, nil
+	}
+	fanIn.closer = func() error {
+		stop()
+		return closeErr
+	}
+	return fanIn
+}
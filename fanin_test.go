@@ -0,0 +1,84 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// An iterator over the items waiting for the delay before returning each of them
+func delayed(items []Item, delay time.Duration) Iterator {
+	computeNext := next(items)
+	return NewDefaultIterator(func() (interface{}, bool, error) {
+		time.Sleep(delay)
+		return computeNext()
+	})
+}
+
+func TestFanIn(t *testing.T) {
+	items, err := Collect(FanIn(
+		Items(generateItems(0, 10)).Iterator(),
+		Items(generateItems(10, 15)).Iterator(),
+		Items(generateItems(15, 30)).Iterator()))
+	assert.Nil(t, err)
+	expected := make([]int, 30)
+	for i := range expected {
+		expected[i] = i
+	}
+	assert.ElementsMatch(t, expected, ids(items...))
+}
+
+func TestFanIn_ArrivalOrder(t *testing.T) {
+	items, err := Collect(FanIn(
+		delayed(generateItems(0, 2), 60*time.Millisecond),
+		delayed(generateItems(2, 5), 5*time.Millisecond)))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 3, 4, 0, 1}, ids(items...))
+}
+
+func TestFanIn_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	blocked := make(chan int)
+	other, closed := closeTracking(generateItems(0, 1000))
+
+	iterator := FanIn(
+		FromChannel(blocked),
+		other,
+		failingIterator(generateItems(0, 3), failure))
+	_, err := Collect(iterator)
+	// the blocked iterator is cancelled and every iterator closed
+	assert.Equal(t, failure, err)
+	assert.True(t, *closed)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestFanIn_KeepsContexts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	other, closed := closeTracking(generateItems(0, 3))
+
+	// the deadline of the input isn't replaced by the context cancelling the inputs
+	_, err := Collect(FanIn(WithContext(ctx, FromChannel(make(chan int))), other))
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, *closed)
+}
+
+func TestFanIn_CloseEarly(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	first, firstClosed := closeTracking(generateItems(0, 1000))
+	second, secondClosed := closeTracking(generateItems(0, 1000))
+	items, err := CollectN(FanIn(first, second), 5)
+	assert.Nil(t, err)
+	assert.Len(t, items, 5)
+	assert.True(t, *firstClosed)
+	assert.True(t, *secondClosed)
+	// the goroutine closing the results may still be exiting
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, runtime.NumGoroutine() <= goroutines)
+}
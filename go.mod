@@ -1,15 +1,21 @@
 module github.com/calvernaz/go-iterators
 
-go 1.13
+go 1.24
 
 require (
+	github.com/pkg/errors v0.8.1
+	github.com/proullon/ramsql v0.0.0-20181213202341-817cee58a244
+	github.com/stretchr/testify v1.4.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/go-sql-driver/mysql v1.4.1 // indirect
 	github.com/mattn/go-sqlite3 v1.11.0 // indirect
 	github.com/onsi/ginkgo v1.10.2 // indirect
 	github.com/onsi/gomega v1.7.0 // indirect
-	github.com/pkg/errors v0.8.1
-	github.com/proullon/ramsql v0.0.0-20181213202341-817cee58a244
-	github.com/stretchr/testify v1.4.0
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/ziutek/mymysql v1.5.4 // indirect
 	google.golang.org/appengine v1.6.5 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
 )
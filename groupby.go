@@ -0,0 +1,91 @@
+package iterator
+
+// KeyFunc returns the key of an element. Keys are compared with ==, so they must be comparable.
+type KeyFunc func(item interface{}) (interface{}, error)
+
+// Group is a run of consecutive elements sharing the same key.
+type Group struct {
+	Key   interface{}
+	Items Iterator
+}
+
+// GroupBy returns an iterator over the groups (Group) of consecutive elements sharing the same key,
+// like Dedup it is meant for sorted iterators, where equal keys are adjacent.
+// The elements of a group are read lazily from the original iterator through the group's Items, which is only valid
+// until the iteration continues: moving to the next group skips the elements left in the current one.
+func GroupBy(it Iterator, keyFn KeyFunc) Iterator {
+	// the next element of the original iterator, already read but not yet returned, and its key
+	var head, headKey interface{}
+	hasHead := false
+	var failure error
+	index := 0
+	readHead := func() (bool, error) {
+		if failure != nil {
+			return false, failure
+		}
+		if hasHead {
+			return true, nil
+		}
+		next, ok, err := pull(it)
+		if err == nil && ok {
+			var key interface{}
+			key, err = keyFn(next)
+			if err != nil {
+				err = &StageError{Op: "GroupBy", Index: index, Err: err}
+			} else {
+				index++
+				head, headKey, hasHead = next, key, true
+			}
+		}
+		failure = err
+		return hasHead, err
+	}
+
+	// the key of the current group and the number of groups returned, a group ends once the next one is returned
+	var key interface{}
+	groups := 0
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			// skips what is left of the current group, whether its elements were read or not
+			if groups > 0 {
+				for {
+					ok, err := readHead()
+					if err != nil {
+						return nil, true, err
+					}
+					if !ok || headKey != key {
+						break
+					}
+					hasHead = false
+				}
+			}
+
+			ok, err := readHead()
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			key = headKey
+			groups++
+			group, groupKey := groups, key
+			items := NewDefaultIterator(func() (interface{}, bool, error) {
+				if group != groups {
+					return nil, true, nil
+				}
+				ok, err := readHead()
+				if err != nil {
+					return nil, true, err
+				}
+				if !ok || headKey != groupKey {
+					return nil, true, nil
+				}
+				hasHead = false
+				return head, false, nil
+			})
+			return Group{Key: groupKey, Items: items}, false, nil
+		},
+		closer: func() error {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
@@ -0,0 +1,104 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tens(item interface{}) (interface{}, error) {
+	return item.(*Item).ID / 10, nil
+}
+
+func TestGroupBy(t *testing.T) {
+	iterator := GroupBy(Items(itemsFromIds(1, 2, 3, 11, 21, 22)).Iterator(), tens)
+
+	groups := map[interface{}][]int{}
+	var keys []interface{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		group := item.(Group)
+		keys = append(keys, group.Key)
+
+		items, err := Collect(group.Items)
+		assert.Nil(t, err)
+		groups[group.Key] = ids(items...)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []interface{}{0, 1, 2}, keys)
+	assert.Equal(t, map[interface{}][]int{0: {1, 2, 3}, 1: {11}, 2: {21, 22}}, groups)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestGroupBy_SkipGroups(t *testing.T) {
+	iterator := GroupBy(Items(itemsFromIds(1, 2, 3, 11, 12, 21, 22)).Iterator(), tens)
+
+	var firsts []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		group := item.(Group)
+		// reads only the first element of each group
+		first, err := group.Items.Next()
+		assert.Nil(t, err)
+		firsts = append(firsts, first.(*Item).ID)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{1, 11, 21}, firsts)
+
+	// groups are not reused once the iteration continues
+	iterator = GroupBy(Items(itemsFromIds(1, 2, 11)).Iterator(), tens)
+	first, _ := iterator.Next()
+	second, _ := iterator.Next()
+	assert.False(t, first.(Group).Items.HasNext())
+	items, err := Collect(second.(Group).Items)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{11}, ids(items...))
+}
+
+func TestGroupBy_CloseGroups(t *testing.T) {
+	iterator := GroupBy(Items(itemsFromIds(1, 2, 3, 11, 21)).Iterator(), tens)
+
+	// First closes the group after its first element
+	var keys []interface{}
+	var firsts []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		group := item.(Group)
+		keys = append(keys, group.Key)
+		first, err := First(group.Items)
+		assert.Nil(t, err)
+		firsts = append(firsts, first.(*Item).ID)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []interface{}{0, 1, 2}, keys)
+	assert.Equal(t, []int{1, 11, 21}, firsts)
+}
+
+func TestGroupBy_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := GroupBy(failingIterator(itemsFromIds(1, 2, 11), failure), tens)
+
+	first, err := iterator.Next()
+	assert.Nil(t, err)
+	items, err := Collect(first.(Group).Items)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, ids(items...))
+
+	second, err := iterator.Next()
+	assert.Nil(t, err)
+	_, err = Collect(second.(Group).Items)
+	assert.Equal(t, failure, err)
+
+	assert.False(t, iterator.HasNext())
+	assert.Equal(t, failure, iterator.Err())
+
+	iterator = GroupBy(Items(itemsFromIds(1, 2)).Iterator(), func(item interface{}) (interface{}, error) {
+		return nil, failure
+	})
+	assert.False(t, iterator.HasNext())
+	assert.True(t, errors.Is(iterator.Err(), failure))
+}
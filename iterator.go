@@ -5,15 +5,18 @@
 package iterator
 
 import (
+	"context"
 	"io"
-
-	"github.com/pkg/errors"
+	"strconv"
 )
 
 // If there is a next element return: next, false, nil
 // If an error occurs computing the next element return: nil, false, error
 // If there is no next element return: nil, true, nil
 type ComputeNext func() (next interface{}, eod bool, err error)
+
+// Same contract as ComputeNext, receiving the context bound to the iterator (see WithContext).
+type ComputeNextCtx func(ctx context.Context) (next interface{}, eod bool, err error)
 type Closer func() error
 
 // An iterator over a stream of data
@@ -21,6 +24,9 @@ type Iterator interface {
 	HasNext() bool
 	Next() (interface{}, error)
 	Peek() (item interface{}, e error)
+	// Err returns the error that stopped the iteration, nil if the end of data was reached.
+	// Check it once HasNext returns false.
+	Err() error
 	io.Closer
 }
 
@@ -37,16 +43,36 @@ const (
 	Failed
 )
 
+func (s State) String() string {
+	switch s {
+	case NotReady:
+		return "NotReady"
+	case Ready:
+		return "Ready"
+	case Done:
+		return "Done"
+	case Failed:
+		return "Failed"
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
 var _ Iterator = (*DefaultIterator)(nil)
 var _ io.Closer = (*DefaultIterator)(nil)
 
 type DefaultIterator struct {
-	state State
-	next  interface{}
-	err   error
+	state  State
+	next   interface{}
+	err    error
+	closed bool
 
 	ComputeNext ComputeNext
 
+	computeNextCtx ComputeNextCtx
+	ctx            context.Context
+	// the iterators this one pulls from, used to propagate the context down the chain
+	sources []Iterator
+
 	closer Closer
 }
 
@@ -65,9 +91,32 @@ func NewCloseableIterator(computeNext ComputeNext, closer Closer) Iterator {
 	}
 }
 
+// Given a way to compute next from a context, returns an iterator
+func NewDefaultIteratorCtx(computeNext ComputeNextCtx) Iterator {
+	return &DefaultIterator{
+		computeNextCtx: computeNext,
+	}
+}
+
+// Given a way to compute next from a context and a close handler, return a closeable iterator
+func NewCloseableIteratorCtx(computeNext ComputeNextCtx, closer Closer) Iterator {
+	return &DefaultIterator{
+		computeNextCtx: computeNext,
+		closer:         closer,
+	}
+}
+
 // Returns true if the iterator can be continued or false if the end of data has been reached.
 // It returns an error if the check fails.
 func (it *DefaultIterator) HasNext() bool {
+	if it.state != Done && it.state != Failed && it.ctx != nil {
+		if err := it.ctx.Err(); err != nil {
+			it.state = Failed
+			it.err = err
+			it.next = nil
+			return false
+		}
+	}
 	switch it.state {
 	case Ready:
 		return true
@@ -75,21 +124,20 @@ func (it *DefaultIterator) HasNext() bool {
 		return false
 	case Failed:
 		return false
-		//, errors.New("metadata iterator in an error state")
 	}
 	return it.tryToComputeNext()
 }
 
 // Returns the next item in the iteration.
 // This method should be always called in combination with the HasNext.
-// If the iterator reached the end of data, the method will return an error
+// If the iterator reached the end of data, the method will return ErrNoSuchElement, ErrClosed if it was closed.
 func (it *DefaultIterator) Next() (interface{}, error) {
 	hasNext := it.HasNext()
 	if it.err != nil {
 		return nil, it.err
 	}
 	if !hasNext {
-		return nil, errors.New("no such element") // is eof an error?
+		return nil, it.exhausted()
 	}
 
 	it.state = NotReady
@@ -102,7 +150,14 @@ func (it *DefaultIterator) Next() (interface{}, error) {
 func (it *DefaultIterator) tryToComputeNext() bool {
 	it.state = Failed // temporary pessimism
 
-	next, eod, err := it.ComputeNext()
+	var next interface{}
+	var eod bool
+	var err error
+	if it.computeNextCtx != nil {
+		next, eod, err = it.computeNextCtx(it.context())
+	} else {
+		next, eod, err = it.ComputeNext()
+	}
 	if err != nil { // we got an err, stated
 		it.state = Failed
 		it.err = err
@@ -119,6 +174,32 @@ func (it *DefaultIterator) tryToComputeNext() bool {
 	return true
 }
 
+// Returns the error that stopped the iteration, nil if the end of data was reached or the iteration is ongoing.
+func (it *DefaultIterator) Err() error {
+	return it.err
+}
+
+// Returns the current state of the iterator.
+func (it *DefaultIterator) State() State {
+	return it.state
+}
+
+// Returns the context bound to the iterator, context.Background() if none.
+func (it *DefaultIterator) context() context.Context {
+	if it.ctx == nil {
+		return context.Background()
+	}
+	return it.ctx
+}
+
+func (it *DefaultIterator) bindContext(ctx context.Context) {
+	if it.ctx != nil && it.ctx != ctx {
+		ctx = mergeContexts(it.ctx, ctx)
+	}
+	it.ctx = ctx
+	bindContext(ctx, it.sources...)
+}
+
 // Returns the next element without continuing the iteration.
 func (it *DefaultIterator) Peek() (interface{}, error) {
 	hasNext := it.HasNext()
@@ -129,14 +210,20 @@ func (it *DefaultIterator) Peek() (interface{}, error) {
 
 	// no more items
 	if !hasNext {
-		return nil, io.EOF
+		return nil, it.exhausted()
 	}
 	next := it.next
 	return next, nil
 }
 
+// Closes the iterator calling the close handler, if any. Closing an iterator more than once has no effect.
 func (it *DefaultIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
 	it.state = Done
+	it.next = nil
 	if it.closer != nil {
 		err := it.closer()
 		if err != nil {
@@ -145,3 +232,10 @@ func (it *DefaultIterator) Close() error {
 	}
 	return nil
 }
+
+func (it *DefaultIterator) exhausted() error {
+	if it.closed {
+		return ErrClosed
+	}
+	return ErrNoSuchElement
+}
@@ -1,6 +1,7 @@
 package iterator
 
 import (
+	stderrors "errors"
 	"testing"
 	
 	"fmt"
@@ -275,3 +276,310 @@ func TestMerge(t *testing.T) {
 	iterator.Close()
 	assert.Equal(t, i, len(expected))
 }
+
+func failingIterator(items []Item, failure error) Iterator {
+	computeNext := next(items)
+	return NewDefaultIterator(func() (interface{}, bool, error) {
+		next, eod, err := computeNext()
+		if eod {
+			return nil, false, failure
+		}
+		return next, eod, err
+	})
+}
+
+func TestErr(t *testing.T) {
+	iterator := NewDefaultIterator(next(generateItems(0, 3)))
+	assert.Equal(t, NotReady, iterator.(*DefaultIterator).State())
+	for iterator.HasNext() {
+		_, err := iterator.Next()
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, Done, iterator.(*DefaultIterator).State())
+
+	failure := errors.New("failure")
+	iterator = failingIterator(generateItems(0, 3), failure)
+	i := 0
+	for iterator.HasNext() {
+		_, err := iterator.Next()
+		assert.Nil(t, err)
+		i++
+	}
+	assert.Equal(t, 3, i)
+	assert.Equal(t, failure, iterator.Err())
+	assert.Equal(t, Failed, iterator.(*DefaultIterator).State())
+	assert.Equal(t, "Failed", Failed.String())
+}
+
+func TestErr_Combinators(t *testing.T) {
+	failure := errors.New("failure")
+	compare := func(item1 interface{}, item2 interface{}) int {
+		return item1.(*Item).ID - item2.(*Item).ID
+	}
+	combinators := map[string]func(it Iterator) Iterator{
+		"Filter": func(it Iterator) Iterator {
+			return Filter(it, func(item interface{}) (bool, error) { return true, nil })
+		},
+		"Transform": func(it Iterator) Iterator {
+			return Transform(it, func(item interface{}) (interface{}, error) { return item, nil })
+		},
+		"Skip":  func(it Iterator) Iterator { return Skip(it, 5) },
+		"Limit": func(it Iterator) Iterator { return Limit(it, 5) },
+		"Concat": func(it Iterator) Iterator {
+			return Concat(it, Items(generateItems(0, 3)).Iterator())
+		},
+		"Merge": func(it Iterator) Iterator {
+			return Merge(compare, it, Items(generateItems(0, 3)).Iterator())
+		},
+		"Dedup": func(it Iterator) Iterator {
+			return Dedup(it, func(item1 interface{}, item2 interface{}) bool { return false })
+		},
+	}
+
+	for name, combinator := range combinators {
+		t.Run(name, func(t *testing.T) {
+			iterator := combinator(failingIterator(generateItems(0, 3), failure))
+			for iterator.HasNext() {
+				_, err := iterator.Next()
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, failure, iterator.Err())
+			_, err := iterator.Next()
+			assert.Equal(t, failure, err)
+		})
+	}
+}
+
+func TestMerge_Stable(t *testing.T) {
+	left := Items{{1, "left"}, {2, "left"}, {2, "left"}}
+	right := Items{{1, "right"}, {2, "right"}, {3, "right"}}
+
+	iterator := Merge(func(item1 interface{}, item2 interface{}) int {
+		return item1.(*Item).ID - item2.(*Item).ID
+	}, left.Iterator(), right.Iterator())
+
+	var got []string
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		myItem := item.(*Item)
+		got = append(got, fmt.Sprintf("%d%s", myItem.ID, myItem.Name))
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []string{"1left", "1right", "2left", "2left", "2right", "3right"}, got)
+}
+
+func TestMerge_NilElements(t *testing.T) {
+	ints := func(values ...interface{}) Iterator {
+		return NewDefaultIterator(func() (interface{}, bool, error) {
+			if len(values) == 0 {
+				return nil, true, nil
+			}
+			next := values[0]
+			values = values[1:]
+			return next, false, nil
+		})
+	}
+	// nil sorts first
+	compare := func(item1 interface{}, item2 interface{}) int {
+		if item1 == nil || item2 == nil {
+			if item1 == item2 {
+				return 0
+			} else if item1 == nil {
+				return -1
+			}
+			return 1
+		}
+		return item1.(int) - item2.(int)
+	}
+
+	iterator := Merge(compare, ints(nil, 2, 4), ints(nil, 1, 3))
+	var got []interface{}
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		got = append(got, item)
+	}
+	assert.Equal(t, []interface{}{nil, nil, 1, 2, 3, 4}, got)
+}
+
+func TestMerge_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Merge(func(item1 interface{}, item2 interface{}) int {
+		return item1.(*Item).ID - item2.(*Item).ID
+	}, Items(itemsFromIds(1, 5, 9)).Iterator(), failingIterator(itemsFromIds(2, 3), failure))
+
+	var ids []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		ids = append(ids, item.(*Item).ID)
+	}
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.Equal(t, failure, iterator.Err())
+}
+
+func TestFlatMap(t *testing.T) {
+	closed := 0
+	iterator := FlatMap(Items(itemsFromIds(0, 1, 2, 3)).Iterator(), func(item interface{}) (Iterator, error) {
+		id := item.(*Item).ID
+		// expands n into n items
+		return NewCloseableIterator(next(generateItems(0, id)), func() error {
+			closed++
+			return nil
+		}), nil
+	})
+
+	var ids []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		ids = append(ids, item.(*Item).ID)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{0, 0, 1, 0, 1, 2}, ids)
+	assert.Equal(t, 4, closed)
+	assert.Nil(t, iterator.Close())
+	assert.Equal(t, 4, closed)
+}
+
+func TestFlatMap_CloseEarly(t *testing.T) {
+	subClosed, sourceClosed := false, false
+	source := NewCloseableIterator(next(generateItems(0, 3)), func() error {
+		sourceClosed = true
+		return nil
+	})
+	iterator := FlatMap(source, func(item interface{}) (Iterator, error) {
+		return NewCloseableIterator(next(generateItems(0, 10)), func() error {
+			subClosed = true
+			return nil
+		}), nil
+	})
+
+	_, err := iterator.Next()
+	assert.Nil(t, err)
+	assert.Nil(t, iterator.Close())
+	assert.True(t, subClosed)
+	assert.True(t, sourceClosed)
+}
+
+func TestFlatMap_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := FlatMap(Items(generateItems(0, 3)).Iterator(), func(item interface{}) (Iterator, error) {
+		if item.(*Item).ID == 1 {
+			return nil, failure
+		}
+		return Items(generateItems(0, 2)).Iterator(), nil
+	})
+
+	i := 0
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+		i++
+	}
+	assert.Equal(t, 2, i)
+	assert.True(t, stderrors.Is(iterator.Err(), failure))
+}
+
+func TestTakeWhile(t *testing.T) {
+	items := generateItems(0, 10)
+	computeNext, idx := nextAndIndex(items)
+	source := NewDefaultIterator(computeNext)
+	iterator := TakeWhile(source, func(item interface{}) (bool, error) {
+		return item.(*Item).ID < 4, nil
+	})
+
+	var got []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		got = append(got, item.(*Item).ID)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{0, 1, 2, 3}, got)
+	// only the first failing element was read, and it is still available
+	assert.Equal(t, 5, *idx)
+	item, err := source.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, item.(*Item).ID)
+}
+
+func TestTakeWhile_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := TakeWhile(Items(generateItems(0, 10)).Iterator(), func(item interface{}) (bool, error) {
+		if item.(*Item).ID == 2 {
+			return false, failure
+		}
+		return true, nil
+	})
+
+	i := 0
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+		i++
+	}
+	assert.Equal(t, 2, i)
+	assert.True(t, stderrors.Is(iterator.Err(), failure))
+}
+
+func TestDropWhile(t *testing.T) {
+	iterator := DropWhile(Items(itemsFromIds(1, 2, 5, 3, 7)).Iterator(), func(item interface{}) (bool, error) {
+		return item.(*Item).ID < 4, nil
+	})
+
+	var got []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		got = append(got, item.(*Item).ID)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{5, 3, 7}, got)
+}
+
+func TestDropWhile_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := DropWhile(Items(generateItems(0, 10)).Iterator(), func(item interface{}) (bool, error) {
+		return false, failure
+	})
+	assert.False(t, iterator.HasNext())
+
+	var stageErr *StageError
+	assert.True(t, stderrors.As(iterator.Err(), &stageErr))
+	assert.Equal(t, "DropWhile", stageErr.Op)
+}
+
+func TestScan(t *testing.T) {
+	iterator := Scan(Items(generateItems(0, 5)).Iterator(), 10, func(acc interface{}, item interface{}) (interface{}, error) {
+		return acc.(int) + item.(*Item).ID, nil
+	})
+
+	var got []interface{}
+	for iterator.HasNext() {
+		next, err := iterator.Next()
+		assert.Nil(t, err)
+		got = append(got, next)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []interface{}{10, 11, 13, 16, 20}, got)
+	iterator.Close()
+}
+
+func TestScan_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Scan(Items(generateItems(0, 5)).Iterator(), 0, func(acc interface{}, item interface{}) (interface{}, error) {
+		if item.(*Item).ID == 3 {
+			return nil, failure
+		}
+		return acc.(int) + item.(*Item).ID, nil
+	})
+
+	i := 0
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+		i++
+	}
+	assert.Equal(t, 3, i)
+	assert.True(t, stderrors.Is(iterator.Err(), failure))
+}
@@ -1,6 +1,9 @@
 package iterator
 
-import "github.com/pkg/errors"
+import (
+	"container/heap"
+	"errors"
+)
 
 // Helper Functions
 
@@ -8,6 +11,7 @@ type PredicateFunc func(item interface{}) (bool, error)
 
 // Creates a wrapper-iterator over the original that will filter elements according to the filter function specified
 func Filter(iter Iterator, test PredicateFunc) Iterator {
+	index := 0
 	return &DefaultIterator{
 		ComputeNext: func() (interface{}, bool, error) {
 			for iter.HasNext() {
@@ -16,18 +20,20 @@ func Filter(iter Iterator, test PredicateFunc) Iterator {
 					return nil, true, err
 				}
 				ok, err := test(ret) // valid predicate
+				if err != nil { // predicate error
+					return nil, false, &StageError{Op: "Filter", Index: index, Err: err}
+				}
+				index++
 				if ok {
 					return ret, false, nil
 				}
-				if err != nil { // predicate error
-					return nil, false, err
-				}
 			}
-			return nil, true, nil
+			return nil, true, iter.Err()
 		},
 		closer: func() error {
 			return iter.Close()
 		},
+		sources: []Iterator{iter},
 	}
 }
 
@@ -42,6 +48,7 @@ type TransformFunc func(item interface{}) (interface{}, error)
 
 // Creates an wrapper-iterator over the original that will transform elements according to the filter function specified
 func Transform(iter Iterator, fn TransformFunc) Iterator {
+	index := 0
 	return &DefaultIterator{
 		ComputeNext: func() (interface{}, bool, error) {
 			for iter.HasNext() {
@@ -51,14 +58,101 @@ func Transform(iter Iterator, fn TransformFunc) Iterator {
 				}
 				
 				nextFn, err := fn(ret)
-				return nextFn, false, err
+				if err != nil {
+					return nil, false, &StageError{Op: "Transform", Index: index, Err: err}
+				}
+				index++
+				return nextFn, false, nil
 			}
-			return nil, true, nil
+			return nil, true, iter.Err()
 		},
 		closer: func() (e error) {
 			return iter.Close()
 		},
+		sources: []Iterator{iter},
+	}
+}
+
+// Creates a wrapper-iterator over the original that will return the running accumulation of the elements:
+// the accumulator obtained combining each element, starting from 'init' which is not returned itself.
+func Scan(iter Iterator, init interface{}, fn ReduceFunc) Iterator {
+	acc := init
+	index := 0
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			next, ok, err := pull(iter)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			acc, err = fn(acc, next)
+			if err != nil {
+				return nil, false, &StageError{Op: "Scan", Index: index, Err: err}
+			}
+			index++
+			return acc, false, nil
+		},
+		closer: func() (e error) {
+			return iter.Close()
+		},
+		sources: []Iterator{iter},
+	}
+}
+
+type FlatMapFunc func(item interface{}) (Iterator, error)
+
+// Creates a wrapper-iterator over the original that will expand every element into the elements of the iterator
+// returned by the function. The produced iterators are consumed lazily, one after the other, and closed once exhausted.
+// Closing the wrapper-iterator closes the current produced iterator and the original one.
+func FlatMap(iter Iterator, fn FlatMapFunc) Iterator {
+	var current Iterator
+	index := 0
+	flat := &DefaultIterator{
+		sources: []Iterator{iter},
+	}
+	flat.ComputeNext = func() (interface{}, bool, error) {
+		for {
+			if current != nil {
+				next, ok, err := pull(current)
+				if err != nil {
+					return nil, true, err
+				}
+				if ok {
+					return next, false, nil
+				}
+				err = current.Close()
+				current = nil
+				if err != nil {
+					return nil, true, err
+				}
+			}
+
+			item, ok, err := pull(iter)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			sub, err := fn(item)
+			if err != nil {
+				return nil, false, &StageError{Op: "FlatMap", Index: index, Err: err}
+			}
+			index++
+			if sub == nil {
+				continue
+			}
+			if flat.ctx != nil {
+				bindContext(flat.ctx, sub)
+			}
+			current = sub
+		}
 	}
+	flat.closer = func() error {
+		var err error
+		if current != nil {
+			err = current.Close()
+			current = nil
+		}
+		return errors.Join(err, iter.Close())
+	}
+	return flat
 }
 
 // Creates an wrapper-iterator over the original that will skip the first 'numberOfElementsToSkip' items
@@ -68,15 +162,17 @@ func Skip(it Iterator, howMany int) Iterator {
 			for howMany > 0 {
 				hasNext:= it.HasNext()
 				if !hasNext {
-					return nil, true, nil
+					return nil, true, it.Err()
+				}
+				if _, err := it.Next(); err != nil {
+					return nil, true, err
 				}
-				_, _ = it.Next()
 				howMany--
 			}
 			
 			hasNext := it.HasNext()
 			if !hasNext {
-				return nil, true, nil
+				return nil, true, it.Err()
 			}
 			
 			ret, err := it.Next()
@@ -88,6 +184,7 @@ func Skip(it Iterator, howMany int) Iterator {
 		closer: func() (e error) {
 			return it.Close()
 		},
+		sources: []Iterator{it},
 	}
 }
 
@@ -102,7 +199,7 @@ func Limit(it Iterator, upperBound int) Iterator {
 			
 			hasNext := it.HasNext()
 			if !hasNext {
-				return nil, true, nil
+				return nil, true, it.Err()
 			}
 			
 			ret, err := it.Next()
@@ -115,6 +212,75 @@ func Limit(it Iterator, upperBound int) Iterator {
 		closer: func() (e error) {
 			return it.Close()
 		},
+		sources: []Iterator{it},
+	}
+}
+
+// Creates a wrapper-iterator over the original that will iterate while the elements satisfy the predicate.
+// The first element failing the predicate is only peeked, so it is left in the original iterator,
+// and nothing more is read from the original once the iteration ends.
+func TakeWhile(it Iterator, test PredicateFunc) Iterator {
+	index := 0
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if !it.HasNext() {
+				return nil, true, it.Err()
+			}
+			peek, err := it.Peek()
+			if err != nil {
+				return nil, true, err
+			}
+			ok, err := test(peek)
+			if err != nil {
+				return nil, false, &StageError{Op: "TakeWhile", Index: index, Err: err}
+			}
+			if !ok {
+				return nil, true, nil
+			}
+			index++
+			ret, err := it.Next()
+			if err != nil {
+				return nil, true, err
+			}
+			return ret, false, nil
+		},
+		closer: func() (e error) {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
+
+// Creates a wrapper-iterator over the original that will skip the elements while they satisfy the predicate,
+// then iterate over all the remaining ones.
+func DropWhile(it Iterator, test PredicateFunc) Iterator {
+	index := 0
+	dropping := true
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			for {
+				ret, ok, err := pull(it)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				if !dropping {
+					return ret, false, nil
+				}
+				drop, err := test(ret)
+				if err != nil {
+					return nil, false, &StageError{Op: "DropWhile", Index: index, Err: err}
+				}
+				index++
+				if !drop {
+					dropping = false
+					return ret, false, nil
+				}
+			}
+		},
+		closer: func() (e error) {
+			return it.Close()
+		},
+		sources: []Iterator{it},
 	}
 }
 
@@ -127,6 +293,9 @@ func Concat(iterators ...Iterator) Iterator {
 			for {
 				hasNext := iterator.HasNext()
 				if !hasNext {
+					if err := iterator.Err(); err != nil {
+						return nil, true, err
+					}
 					_ = iterator.Close()
 					currentIteratorIdx ++
 					if currentIteratorIdx < len(iterators) {
@@ -144,19 +313,9 @@ func Concat(iterators ...Iterator) Iterator {
 			}
 		},
 		closer: func() (e error) {
-			var err error
-			for _, it := range iterators {
-				tmpErr := it.Close()
-				if tmpErr != nil {
-					if err != nil {
-						err = tmpErr
-					} else {
-						err = errors.Wrap(err, tmpErr.Error())
-					}
-				}
-			}
-			return err
+			return closeAll(iterators)
 		},
+		sources: iterators,
 	}
 }
 
@@ -164,34 +323,50 @@ func Concat(iterators ...Iterator) Iterator {
 type CompareFunc func(item1 interface{}, item2 interface{}) int
 
 // Merge combines multiple sorted iterators into a single sorted iterator.
+// The head of every iterator is kept in a min-heap, so each element costs O(log k) comparisons.
+// Equal elements are returned in the order of the iterators they come from.
 func Merge(compareFn CompareFunc, iterators ...Iterator) Iterator {
+	var failure error
+	var heads *mergeHeap
 	return &DefaultIterator{
 		ComputeNext: func() (interface{}, bool, error) {
-			for {
-				ret, err := selectMin(compareFn, iterators...)
-				if err != nil {
-					return nil, true, err
-				}
-				if ret == nil {
-					return nil, true, nil
-				}
-				return ret, false, nil
-			}
-		},
-		closer: func() (e error) {
-			var err error
-			for _, it := range iterators {
-				tmpErr := it.Close()
-				if tmpErr != nil {
+			if heads == nil {
+				heads = &mergeHeap{compareFn: compareFn}
+				for i, it := range iterators {
+					next, ok, err := pull(it)
 					if err != nil {
-						err = tmpErr
-					} else {
-						err = errors.Wrap(err, tmpErr.Error())
+						return nil, true, err
+					}
+					if ok {
+						heads.entries = append(heads.entries, mergeEntry{item: next, index: i})
 					}
 				}
+				heap.Init(heads)
+			}
+			if failure != nil {
+				return nil, true, failure
 			}
-			return err
+			if heads.Len() == 0 {
+				return nil, true, nil
+			}
+
+			// the failure of the iterator is reported after its last element
+			min := heads.entries[0]
+			next, ok, err := pull(iterators[min.index])
+			if err != nil {
+				failure = err
+			} else if ok {
+				heads.entries[0].item = next
+				heap.Fix(heads, 0)
+			} else {
+				heap.Pop(heads)
+			}
+			return min.item, false, nil
+		},
+		closer: func() (e error) {
+			return closeAll(iterators)
 		},
+		sources: iterators,
 	}
 }
 
@@ -214,38 +389,53 @@ func Dedup(it Iterator, equalsFn EqualsFunc) Iterator {
 					return ret, false, nil
 				}
 			}
-			return nil, true, nil
+			return nil, true, it.Err()
 		},
 		closer: func() (e error) {
 			return it.Close()
 		},
+		sources: []Iterator{it},
 	}
 }
 
-func selectMin(compareFn CompareFunc, iterators ...Iterator) (interface{}, error) {
-	var selected int
-	var current interface{}
-	for i, it := range iterators {
-		hasNext := it.HasNext()
-		if hasNext {
-			peek, err := it.Peek()
-			if err != nil {
-				return nil, err
-			}
-			
-			if current == nil {
-				current = peek
-				selected = i
-			} else if compareFn(current, peek) > 0 { // The peek is lower than the current selection
-				current = peek
-				selected = i
-			}
-		}
+// The head of one of the merged iterators
+type mergeEntry struct {
+	item  interface{}
+	index int
+}
+
+// A min-heap of the merged iterators heads, ties are resolved by iterator index
+type mergeHeap struct {
+	entries   []mergeEntry
+	compareFn CompareFunc
+}
+
+func (h *mergeHeap) Len() int { return len(h.entries) }
+
+func (h *mergeHeap) Less(i, j int) bool {
+	c := h.compareFn(h.entries[i].item, h.entries[j].item)
+	return c < 0 || (c == 0 && h.entries[i].index < h.entries[j].index)
+}
+
+func (h *mergeHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *mergeHeap) Push(x interface{}) { h.entries = append(h.entries, x.(mergeEntry)) }
+
+func (h *mergeHeap) Pop() interface{} {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// pull returns the next element of the iterator and true, or false once the iterator has no more elements.
+// The error stopping the iterator is returned, if any.
+func pull(it Iterator) (interface{}, bool, error) {
+	if !it.HasNext() {
+		return nil, false, it.Err()
 	}
-	if current != nil {
-		_, _ = iterators[selected].Next()
-		return current, nil
+	next, err := it.Next()
+	if err != nil {
+		return nil, false, err
 	}
-	return nil, nil
-	
+	return next, true, nil
 }
@@ -0,0 +1,160 @@
+package iterator
+
+// JoinType tells which elements without a match are returned by MergeJoin.
+type JoinType int
+
+const (
+	// Only the matching elements
+	InnerJoin JoinType = iota
+	// The matching elements and the left elements without a match
+	LeftJoin
+	// The matching elements and the right elements without a match
+	RightJoin
+	// The matching elements and the elements without a match of both sides
+	FullJoin
+)
+
+// MergeJoin joins two iterators sorted by key, returning a Pair for every left and right elements with the same key,
+// left as First and right as Second. The compare function compares a left element to a right one.
+// Elements without a match, if the join type includes them, are paired with nil.
+// Runs of elements sharing the same key are joined many-to-many, only these runs are held in memory.
+func MergeJoin(left, right Iterator, compareFn CompareFunc, joinType JoinType) Iterator {
+	l, r := &lookahead{it: left}, &lookahead{it: right}
+	keepLeft := joinType == LeftJoin || joinType == FullJoin
+	keepRight := joinType == RightJoin || joinType == FullJoin
+	var pending []Pair
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			for {
+				if len(pending) > 0 {
+					pair := pending[0]
+					pending = pending[1:]
+					return pair, false, nil
+				}
+
+				okL, err := l.head()
+				if err != nil {
+					return nil, true, err
+				}
+				if !okL && !keepRight {
+					return nil, true, nil
+				}
+				okR, err := r.head()
+				if err != nil {
+					return nil, true, err
+				}
+				if !okR && !keepLeft {
+					return nil, true, nil
+				}
+
+				var c int
+				switch {
+				case !okL && !okR:
+					return nil, true, nil
+				case !okL:
+					c = 1
+				case !okR:
+					c = -1
+				default:
+					c = compareFn(l.item, r.item)
+				}
+
+				if c < 0 {
+					if item := l.take(); keepLeft {
+						return Pair{First: item}, false, nil
+					}
+					continue
+				}
+				if c > 0 {
+					if item := r.take(); keepRight {
+						return Pair{Second: item}, false, nil
+					}
+					continue
+				}
+
+				// the runs of left and right elements sharing the key
+				firstRight := r.item
+				var lefts, rights []interface{}
+				for ok := true; ok && compareFn(l.item, firstRight) == 0; {
+					lefts = append(lefts, l.take())
+					if ok, err = l.head(); err != nil {
+						return nil, true, err
+					}
+				}
+				for ok := true; ok && compareFn(lefts[0], r.item) == 0; {
+					rights = append(rights, r.take())
+					if ok, err = r.head(); err != nil {
+						return nil, true, err
+					}
+				}
+				for _, leftItem := range lefts {
+					for _, rightItem := range rights {
+						pending = append(pending, Pair{First: leftItem, Second: rightItem})
+					}
+				}
+			}
+		},
+		closer: func() error {
+			return closeAll([]Iterator{left, right})
+		},
+		sources: []Iterator{left, right},
+	}
+}
+
+// HashJoin joins two iterators, not necessarily sorted, returning a Pair for every build and probe elements with the
+// same key, build as First and probe as Second, in the order of the probe iterator.
+// The build iterator, meant to be the smaller one, is read in full and held in memory on the first call to HasNext
+// or Next, while the probe iterator is read lazily. The key function is used on the elements of both iterators.
+func HashJoin(build, probe Iterator, keyFn KeyFunc) Iterator {
+	var table map[interface{}][]interface{}
+	var pending []Pair
+	index := 0
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if table == nil {
+				table = map[interface{}][]interface{}{}
+				buildIndex := 0
+				for {
+					next, ok, err := pull(build)
+					if err != nil {
+						return nil, true, err
+					}
+					if !ok {
+						break
+					}
+					key, err := keyFn(next)
+					if err != nil {
+						return nil, true, &StageError{Op: "HashJoin", Index: buildIndex, Err: err}
+					}
+					buildIndex++
+					table[key] = append(table[key], next)
+				}
+				if err := build.Close(); err != nil {
+					return nil, true, err
+				}
+			}
+
+			for len(pending) == 0 {
+				next, ok, err := pull(probe)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				key, err := keyFn(next)
+				if err != nil {
+					return nil, false, &StageError{Op: "HashJoin", Index: index, Err: err}
+				}
+				index++
+				for _, match := range table[key] {
+					pending = append(pending, Pair{First: match, Second: next})
+				}
+			}
+			pair := pending[0]
+			pending = pending[1:]
+			return pair, false, nil
+		},
+		closer: func() error {
+			return closeAll([]Iterator{build, probe})
+		},
+		sources: []Iterator{build, probe},
+	}
+}
@@ -0,0 +1,104 @@
+package iterator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	userID int
+	street string
+}
+
+func addresses(addrs ...address) Iterator {
+	items := make([]interface{}, len(addrs))
+	for i := range addrs {
+		items[i] = &addrs[i]
+	}
+	return fromSlice(items)
+}
+
+func compareUserAddress(item1 interface{}, item2 interface{}) int {
+	return item1.(*Item).ID - item2.(*address).userID
+}
+
+// formats the joined pairs as "user:street", "-" standing for a missing side
+func joined(t *testing.T, iterator Iterator) []string {
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	var result []string
+	for _, item := range items {
+		pair := item.(Pair)
+		user, street := "-", "-"
+		if pair.First != nil {
+			user = pair.First.(*Item).Name
+		}
+		if pair.Second != nil {
+			street = pair.Second.(*address).street
+		}
+		result = append(result, fmt.Sprintf("%s:%s", user, street))
+	}
+	return result
+}
+
+func TestMergeJoin(t *testing.T) {
+	users := func() Iterator {
+		return Items{{1, "ann"}, {2, "bob"}, {2, "ben"}, {4, "dan"}}.Iterator()
+	}
+	streets := func() Iterator {
+		return addresses(address{0, "zero"}, address{2, "main"}, address{2, "high"}, address{3, "mill"}, address{4, "park"})
+	}
+
+	tests := []struct {
+		joinType JoinType
+		expected []string
+	}{
+		{InnerJoin, []string{"bob:main", "bob:high", "ben:main", "ben:high", "dan:park"}},
+		{LeftJoin, []string{"ann:-", "bob:main", "bob:high", "ben:main", "ben:high", "dan:park"}},
+		{RightJoin, []string{"-:zero", "bob:main", "bob:high", "ben:main", "ben:high", "-:mill", "dan:park"}},
+		{FullJoin, []string{"-:zero", "ann:-", "bob:main", "bob:high", "ben:main", "ben:high", "-:mill", "dan:park"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, joined(t, MergeJoin(users(), streets(), compareUserAddress, test.joinType)))
+	}
+}
+
+func TestMergeJoin_Unmatched(t *testing.T) {
+	users := Items{{5, "eve"}, {6, "fay"}}.Iterator()
+	streets := addresses(address{1, "main"})
+	assert.Equal(t, []string{"-:main", "eve:-", "fay:-"}, joined(t, MergeJoin(users, streets, compareUserAddress, FullJoin)))
+}
+
+func TestMergeJoin_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := MergeJoin(failingIterator(Items{{1, "ann"}}, failure), addresses(address{1, "main"}), compareUserAddress, InnerJoin)
+	_, err := Collect(iterator)
+	assert.Equal(t, failure, err)
+}
+
+func TestHashJoin(t *testing.T) {
+	users := Items{{4, "dan"}, {2, "bob"}, {1, "ann"}, {2, "ben"}}.Iterator()
+	streets := addresses(address{2, "main"}, address{3, "mill"}, address{4, "park"}, address{2, "high"})
+	key := func(item interface{}) (interface{}, error) {
+		switch v := item.(type) {
+		case *Item:
+			return v.ID, nil
+		case *address:
+			return v.userID, nil
+		}
+		return nil, fmt.Errorf("unexpected item %v", item)
+	}
+
+	assert.Equal(t, []string{"bob:main", "ben:main", "dan:park", "bob:high", "ben:high"}, joined(t, HashJoin(users, streets, key)))
+}
+
+func TestHashJoin_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	_, err := Collect(HashJoin(Items(generateItems(0, 2)).Iterator(), Items(generateItems(0, 2)).Iterator(), func(item interface{}) (interface{}, error) {
+		return nil, failure
+	}))
+	assert.True(t, errors.Is(err, failure))
+}
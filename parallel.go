@@ -0,0 +1,142 @@
+package iterator
+
+import "sync"
+
+type ParallelOptions struct {
+	// Maximum number of elements read from the original and not yet returned, twice the workers if zero.
+	MaxInFlight int
+	// Return the elements as soon as they are transformed rather than in the original order.
+	Unordered bool
+}
+
+// An element transformed by ParallelTransform, with its position in the original iterator
+type sequenced struct {
+	result
+	seq int
+}
+
+// ParallelTransform is like Transform but calls the transform function on the given number of goroutines.
+// Up to opts.MaxInFlight elements are read ahead from the original iterator, the transformed elements are returned
+// in the original order unless opts.Unordered is set; errors are reported in the same order as elements.
+// Closing the iterator stops the workers, waiting for the in-flight calls to return, then closes the original.
+func ParallelTransform(it Iterator, fn TransformFunc, workers int, opts ParallelOptions) Iterator {
+	if workers < 1 {
+		panic("iterator: workers must be positive")
+	}
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight < 1 {
+		maxInFlight = 2 * workers
+	}
+
+	var results chan sequenced
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	slots := make(chan struct{}, maxInFlight)
+	start := func() {
+		jobs := make(chan sequenced)
+		results = make(chan sequenced)
+		// the error stopping the original, sent once the workers delivered the elements read before it
+		var failure *sequenced
+
+		// reads the original
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			for seq := 0; ; seq++ {
+				select {
+				case slots <- struct{}{}:
+				case <-done:
+					return
+				}
+				next, ok, err := pull(it)
+				if !ok {
+					if err != nil {
+						failure = &sequenced{result: result{err: err}, seq: seq}
+					}
+					return
+				}
+				select {
+				case jobs <- sequenced{result: result{item: next}, seq: seq}:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		var workersWg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			workersWg.Add(1)
+			go func() {
+				defer workersWg.Done()
+				for job := range jobs {
+					item, err := fn(job.item)
+					if err != nil {
+						err = &StageError{Op: "ParallelTransform", Index: job.seq, Err: err}
+					}
+					select {
+					case results <- sequenced{result: result{item: item, err: err}, seq: job.seq}:
+					case <-done:
+						return
+					}
+				}
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workersWg.Wait()
+			// the reader is done as well, once the workers drained the jobs
+			if failure != nil {
+				select {
+				case results <- *failure:
+				case <-done:
+				}
+			}
+			close(results)
+		}()
+	}
+
+	pending := map[int]sequenced{}
+	expected := 0
+	stop := sync.OnceFunc(func() {
+		close(done)
+		wg.Wait()
+	})
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if results == nil {
+				start()
+			}
+			for {
+				r, ok := pending[expected]
+				if !ok {
+					received, open := <-results
+					if !open {
+						return nil, true, nil
+					}
+					if !opts.Unordered {
+						pending[received.seq] = received
+						continue
+					}
+					r = received
+				}
+				delete(pending, expected)
+				expected++
+
+				if r.err != nil {
+					return nil, false, r.err
+				}
+				// frees the slot of the element
+				<-slots
+				return r.item, false, nil
+			}
+		},
+		closer: func() error {
+			stop()
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
@@ -0,0 +1,97 @@
+package iterator
+
+import (
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sleeps a random time, so that the workers complete out of order
+func slowId(item interface{}) (interface{}, error) {
+	time.Sleep(time.Duration(rand.Intn(1000)) * time.Microsecond)
+	return item.(*Item).ID, nil
+}
+
+func TestParallelTransform(t *testing.T) {
+	iterator := ParallelTransform(Items(generateItems(0, 100)).Iterator(), slowId, 8, ParallelOptions{})
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	assert.Len(t, items, 100)
+	for i, item := range items {
+		assert.Equal(t, i, item)
+	}
+}
+
+func TestParallelTransform_Unordered(t *testing.T) {
+	iterator := ParallelTransform(Items(generateItems(0, 100)).Iterator(), slowId, 8, ParallelOptions{Unordered: true})
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	assert.Len(t, items, 100)
+	assert.ElementsMatch(t, ids(mapItems(generateItems(0, 100))...), items)
+}
+
+func TestParallelTransform_BoundedInFlight(t *testing.T) {
+	computeNext, idx := nextAndIndex(generateItems(0, 100))
+	var read int32
+	source := NewDefaultIterator(func() (interface{}, bool, error) {
+		atomic.AddInt32(&read, 1)
+		return computeNext()
+	})
+	iterator := ParallelTransform(source, slowId, 2, ParallelOptions{MaxInFlight: 4})
+
+	_, err := iterator.Next()
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	// the returned element plus those in flight
+	assert.True(t, atomic.LoadInt32(&read) <= 6, "read %d", atomic.LoadInt32(&read))
+	assert.Nil(t, iterator.Close())
+	assert.True(t, *idx <= 6)
+}
+
+func TestParallelTransform_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := ParallelTransform(Items(generateItems(0, 100)).Iterator(), func(item interface{}) (interface{}, error) {
+		id := item.(*Item).ID
+		if id == 30 || id == 60 {
+			return nil, failure
+		}
+		return slowId(item)
+	}, 8, ParallelOptions{})
+
+	items, err := Collect(iterator)
+	assert.Len(t, items, 30)
+	assert.True(t, errors.Is(err, failure))
+	var stageErr *StageError
+	assert.True(t, errors.As(err, &stageErr))
+	assert.Equal(t, 30, stageErr.Index)
+
+	items, err = Collect(ParallelTransform(failingIterator(generateItems(0, 20), failure), slowId, 4, ParallelOptions{}))
+	assert.Len(t, items, 20)
+	assert.Equal(t, failure, err)
+
+	// the error of the original comes after its elements, even when unordered
+	items, err = Collect(ParallelTransform(failingIterator(generateItems(0, 20), failure), slowId, 4, ParallelOptions{Unordered: true}))
+	assert.Len(t, items, 20)
+	assert.Equal(t, failure, err)
+}
+
+func TestParallelTransform_CloseEarly(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 1000))
+	iterator := ParallelTransform(source, slowId, 4, ParallelOptions{})
+	items, err := CollectN(iterator, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, items)
+	assert.True(t, *closed)
+}
+
+func mapItems(items []Item) []interface{} {
+	result := make([]interface{}, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package iterator
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync"
+)
+
+// RouteFunc returns the partition of an element, between 0 and n-1.
+type RouteFunc func(item interface{}, n int) (int, error)
+
+// HashRoute routes the elements by the hash of their key modulo the number of partitions,
+// so that the elements with the same key end up in the same partition. Keys must be comparable.
+func HashRoute(keyFn KeyFunc) RouteFunc {
+	seed := maphash.MakeSeed()
+	return func(item interface{}, n int) (int, error) {
+		key, err := keyFn(item)
+		if err != nil {
+			return 0, err
+		}
+		return int(maphash.Comparable(seed, key) % uint64(n)), nil
+	}
+}
+
+type PartitionOptions struct {
+	// Maximum number of elements buffered for every partition, 64 if zero.
+	BufferSize int
+}
+
+// Partition splits the iterator into n iterators (partitions), routing every element to one of them.
+// Each partition is meant to be consumed on its own goroutine: the original is read on a background goroutine
+// as soon as one of the partitions is, and reading stops while the buffer of the partition of the next element
+// is full. The elements routed to a closed partition are dropped. The error stopping the original is returned by
+// every partition after its elements. The original iterator is closed once all partitions are closed.
+func Partition(it Iterator, n int, routeFn RouteFunc) []Iterator {
+	return PartitionWithOptions(it, n, routeFn, PartitionOptions{})
+}
+
+// PartitionWithOptions is like Partition with the given options.
+func PartitionWithOptions(it Iterator, n int, routeFn RouteFunc, opts PartitionOptions) []Iterator {
+	if n < 1 {
+		panic("iterator: number of partitions must be positive")
+	}
+	bufferSize := opts.BufferSize
+	if bufferSize < 1 {
+		bufferSize = 64
+	}
+
+	p := &partitioner{
+		source:  it,
+		routeFn: routeFn,
+		outs:    make([]chan result, n),
+		closed:  make([]chan struct{}, n),
+		open:    n,
+		done:    make(chan struct{}),
+	}
+	partitions := make([]Iterator, n)
+	for i := range partitions {
+		p.outs[i] = make(chan result, bufferSize)
+		p.closed[i] = make(chan struct{})
+		partitions[i] = p.partition(i)
+	}
+	return partitions
+}
+
+// The state shared by the partitions of a Partition
+type partitioner struct {
+	source  Iterator
+	routeFn RouteFunc
+	// the elements of every partition, and a channel closed once the partition is closed
+	outs   []chan result
+	closed []chan struct{}
+
+	mu   sync.Mutex
+	open int
+
+	start sync.Once
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func (p *partitioner) partition(i int) Iterator {
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			p.start.Do(p.dispatch)
+			r, ok := <-p.outs[i]
+			if !ok {
+				return nil, true, nil
+			}
+			if r.err != nil {
+				return nil, false, r.err
+			}
+			return r.item, false, nil
+		},
+		closer: func() error {
+			return p.close(i)
+		},
+		sources: []Iterator{p.source},
+	}
+}
+
+// dispatch starts reading the original on a new goroutine, sending every element to its partition.
+func (p *partitioner) dispatch() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			for _, out := range p.outs {
+				close(out)
+			}
+		}()
+		for index := 0; ; index++ {
+			select {
+			case <-p.done:
+				return
+			default:
+			}
+
+			next, ok, err := pull(p.source)
+			if !ok {
+				if err != nil {
+					p.broadcast(err)
+				}
+				return
+			}
+			i, err := p.routeFn(next, len(p.outs))
+			if err == nil && (i < 0 || i >= len(p.outs)) {
+				err = fmt.Errorf("partition %d out of range [0, %d)", i, len(p.outs))
+			}
+			if err != nil {
+				p.broadcast(&StageError{Op: "Partition", Index: index, Err: err})
+				return
+			}
+			if !p.send(i, result{item: next}) {
+				return
+			}
+		}
+	}()
+}
+
+// send sends the result to the partition, unless it is closed. It returns false once every partition is closed.
+func (p *partitioner) send(i int, r result) bool {
+	select {
+	case p.outs[i] <- r:
+	case <-p.closed[i]:
+	case <-p.done:
+		return false
+	}
+	return true
+}
+
+// broadcast sends the error to every partition.
+func (p *partitioner) broadcast(err error) {
+	for i := range p.outs {
+		if !p.send(i, result{err: err}) {
+			return
+		}
+	}
+}
+
+func (p *partitioner) close(i int) error {
+	p.mu.Lock()
+	close(p.closed[i])
+	p.open--
+	last := p.open == 0
+	p.mu.Unlock()
+	if !last {
+		return nil
+	}
+
+	// waits for an in-flight call to the original to return
+	close(p.done)
+	p.wg.Wait()
+	return p.source.Close()
+}
@@ -0,0 +1,148 @@
+package iterator
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func byIdModulo(item interface{}, n int) (int, error) {
+	return item.(*Item).ID % n, nil
+}
+
+// collectAll collects every iterator on its own goroutine
+func collectAll(iterators []Iterator) ([][]interface{}, []error) {
+	items := make([][]interface{}, len(iterators))
+	errs := make([]error, len(iterators))
+	var wg sync.WaitGroup
+	for i, it := range iterators {
+		wg.Add(1)
+		go func(i int, it Iterator) {
+			defer wg.Done()
+			items[i], errs[i] = Collect(it)
+		}(i, it)
+	}
+	wg.Wait()
+	return items, errs
+}
+
+func TestPartition(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 300))
+	partitions := PartitionWithOptions(source, 3, byIdModulo, PartitionOptions{BufferSize: 4})
+
+	items, errs := collectAll(partitions)
+	for i := range partitions {
+		assert.Nil(t, errs[i])
+		assert.Len(t, items[i], 100)
+		for j, item := range items[i] {
+			assert.Equal(t, 3*j+i, item.(*Item).ID)
+		}
+	}
+	assert.True(t, *closed)
+}
+
+func TestPartition_HashRoute(t *testing.T) {
+	partitions := Partition(Items(generateItems(0, 100)).Iterator(), 4, HashRoute(func(item interface{}) (interface{}, error) {
+		return item.(*Item).ID % 10, nil
+	}))
+
+	items, errs := collectAll(partitions)
+	total := 0
+	for i := range partitions {
+		assert.Nil(t, errs[i])
+		total += len(items[i])
+	}
+	assert.Equal(t, 100, total)
+	// every key is in a single partition
+	for key := 0; key < 10; key++ {
+		found := 0
+		for i := range partitions {
+			for _, item := range items[i] {
+				if item.(*Item).ID%10 == key {
+					found++
+					break
+				}
+			}
+		}
+		assert.Equal(t, 1, found)
+	}
+}
+
+func TestPartition_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	partitions := Partition(failingIterator(generateItems(0, 10), failure), 2, byIdModulo)
+
+	items, errs := collectAll(partitions)
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, ids(items[0]...))
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, ids(items[1]...))
+	assert.Equal(t, failure, errs[0])
+	assert.Equal(t, failure, errs[1])
+}
+
+func TestPartition_WhenRouteFails(t *testing.T) {
+	failure := errors.New("failure")
+	partitions := Partition(Items(generateItems(0, 10)).Iterator(), 2, func(item interface{}, n int) (int, error) {
+		if item.(*Item).ID == 4 {
+			return 0, failure
+		}
+		return byIdModulo(item, n)
+	})
+
+	items, errs := collectAll(partitions)
+	assert.Equal(t, []int{0, 2}, ids(items[0]...))
+	assert.Equal(t, []int{1, 3}, ids(items[1]...))
+	for _, err := range errs {
+		var stageErr *StageError
+		assert.True(t, errors.As(err, &stageErr))
+		assert.Equal(t, 4, stageErr.Index)
+		assert.True(t, errors.Is(err, failure))
+	}
+
+	// out of range
+	partitions = Partition(Items(generateItems(0, 10)).Iterator(), 2, func(item interface{}, n int) (int, error) {
+		return n, nil
+	})
+	_, errs = collectAll(partitions)
+	assert.NotNil(t, errs[0])
+	assert.NotNil(t, errs[1])
+}
+
+func TestPartition_ClosedPartition(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 100))
+	partitions := PartitionWithOptions(source, 2, byIdModulo, PartitionOptions{BufferSize: 1})
+
+	// the elements of the closed partition are dropped
+	assert.Nil(t, partitions[1].Close())
+	assert.False(t, *closed)
+	items, err := Collect(partitions[0])
+	assert.Nil(t, err)
+	assert.Len(t, items, 50)
+	assert.True(t, *closed)
+}
+
+func TestPartition_BoundedBuffer(t *testing.T) {
+	var read int32
+	computeNext := next(generateItems(0, 100))
+	source := NewDefaultIterator(func() (interface{}, bool, error) {
+		atomic.AddInt32(&read, 1)
+		return computeNext()
+	})
+	partitions := PartitionWithOptions(source, 2, byIdModulo, PartitionOptions{BufferSize: 3})
+
+	_, err := partitions[0].Next()
+	assert.Nil(t, err)
+	// the second partition isn't consumed: 3 of its elements are buffered and one is waiting to be sent
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&read) == 8
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(8), atomic.LoadInt32(&read))
+
+	for _, partition := range partitions {
+		assert.Nil(t, partition.Close())
+	}
+}
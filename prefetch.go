@@ -0,0 +1,35 @@
+package iterator
+
+// Prefetch reads up to n elements ahead of the caller on a background goroutine, so that computing the next elements
+// overlaps with the processing of the current one. Errors are returned in the same position as in the original.
+// Closing the iterator stops the goroutine, waiting for an in-flight call to the original to return,
+// then closes the original.
+func Prefetch(it Iterator, n int) Iterator {
+	if n < 1 {
+		panic("iterator: prefetch size must be positive")
+	}
+	var pumped *pumping
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if pumped == nil {
+				// the pump holds one more element while waiting to send it
+				pumped = startPumping(it, n-1)
+			}
+			r, ok := <-pumped.out
+			if !ok {
+				return nil, true, nil
+			}
+			if r.err != nil {
+				return nil, false, r.err
+			}
+			return r.item, false, nil
+		},
+		closer: func() error {
+			if pumped != nil {
+				pumped.stop()
+			}
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
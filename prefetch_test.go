@@ -0,0 +1,57 @@
+package iterator
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefetch(t *testing.T) {
+	items, err := Collect(Prefetch(Items(generateItems(0, 100)).Iterator(), 8))
+	assert.Nil(t, err)
+	assert.Len(t, items, 100)
+	for i, item := range items {
+		assert.Equal(t, i, item.(*Item).ID)
+	}
+}
+
+func TestPrefetch_ReadsAhead(t *testing.T) {
+	var read int32
+	computeNext := next(generateItems(0, 100))
+	source := NewDefaultIterator(func() (interface{}, bool, error) {
+		atomic.AddInt32(&read, 1)
+		return computeNext()
+	})
+	iterator := Prefetch(source, 5)
+
+	_, err := iterator.Next()
+	assert.Nil(t, err)
+	// the returned element and the 5 ahead of it
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&read) == 6
+	}, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&read))
+	assert.Nil(t, iterator.Close())
+}
+
+func TestPrefetch_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	items, err := Collect(Prefetch(failingIterator(generateItems(0, 10), failure), 3))
+	assert.Len(t, items, 10)
+	assert.Equal(t, failure, err)
+}
+
+func TestPrefetch_CloseEarly(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+	source, closed := closeTracking(generateItems(0, 1000))
+	items, err := CollectN(Prefetch(source, 4), 3)
+	assert.Nil(t, err)
+	assert.Len(t, items, 3)
+	assert.True(t, *closed)
+	assert.Equal(t, goroutines, runtime.NumGoroutine())
+}
@@ -0,0 +1,64 @@
+package iterator
+
+import "sync"
+
+// An element, or the error that stopped the iteration, handed over between goroutines
+type result struct {
+	item interface{}
+	err  error
+}
+
+// pump drains the iterator sending its elements to out, followed by the error that stopped it if any.
+// It returns at the end of data, after sending an error or as soon as done is closed, whichever comes first.
+func pump(it Iterator, out chan<- result, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		next, ok, err := pull(it)
+		if !ok && err == nil {
+			return
+		}
+		select {
+		case out <- result{item: next, err: err}:
+		case <-done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// A goroutine pumping an iterator into a channel, closed once the pump returns
+type pumping struct {
+	out  chan result
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+func startPumping(it Iterator, buffer int) *pumping {
+	p := &pumping{
+		out:  make(chan result, buffer),
+		done: make(chan struct{}),
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(p.out)
+		pump(it, p.out, p.done)
+	}()
+	return p
+}
+
+// stop stops the pump, waiting for an in-flight call to the iterator to return.
+func (p *pumping) stop() {
+	p.once.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+	})
+}
@@ -0,0 +1,174 @@
+package iterator
+
+import "errors"
+
+// Terminal functions, consuming the iterator. They always close the iterator and report the error that stopped
+// the iteration, if any.
+
+type ReduceFunc func(acc interface{}, item interface{}) (interface{}, error)
+
+// Collect returns all the elements of the iterator.
+func Collect(it Iterator) ([]interface{}, error) {
+	return CollectN(it, -1)
+}
+
+// CollectN returns up to n elements of the iterator, all of them if n is negative.
+func CollectN(it Iterator, n int) ([]interface{}, error) {
+	if n == 0 {
+		return nil, it.Close()
+	}
+	var items []interface{}
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		items = append(items, item)
+		return index+1 != n, nil
+	})
+	return items, err
+}
+
+// Count returns the number of elements of the iterator.
+func Count(it Iterator) (int, error) {
+	count := 0
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		count++
+		return true, nil
+	})
+	return count, err
+}
+
+// Reduce combines the elements of the iterator using the first one as initial accumulator.
+// It returns ErrNoSuchElement if the iterator has no elements.
+func Reduce(it Iterator, fn ReduceFunc) (interface{}, error) {
+	var acc interface{}
+	found := false
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		if index == 0 {
+			acc, found = item, true
+			return true, nil
+		}
+		var err error
+		acc, err = fn(acc, item)
+		if err != nil {
+			return false, &StageError{Op: "Reduce", Index: index, Err: err}
+		}
+		return true, nil
+	})
+	if err == nil && !found {
+		return nil, ErrNoSuchElement
+	}
+	return acc, err
+}
+
+// Fold combines the elements of the iterator starting from the initial accumulator.
+func Fold(it Iterator, init interface{}, fn ReduceFunc) (interface{}, error) {
+	acc := init
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		var err error
+		acc, err = fn(acc, item)
+		if err != nil {
+			return false, &StageError{Op: "Fold", Index: index, Err: err}
+		}
+		return true, nil
+	})
+	return acc, err
+}
+
+// First returns the first element of the iterator, ErrNoSuchElement if it has no elements.
+func First(it Iterator) (interface{}, error) {
+	return Find(it, func(item interface{}) (bool, error) {
+		return true, nil
+	})
+}
+
+// Last returns the last element of the iterator, ErrNoSuchElement if it has no elements.
+func Last(it Iterator) (interface{}, error) {
+	var last interface{}
+	found := false
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		last, found = item, true
+		return true, nil
+	})
+	if err == nil && !found {
+		return nil, ErrNoSuchElement
+	}
+	return last, err
+}
+
+// Find returns the first element satisfying the predicate, ErrNoSuchElement if there is none.
+func Find(it Iterator, test PredicateFunc) (interface{}, error) {
+	var found interface{}
+	ok := false
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		var err error
+		ok, err = test(item)
+		if err != nil {
+			return false, &StageError{Op: "Find", Index: index, Err: err}
+		}
+		if ok {
+			found = item
+		}
+		return !ok, nil
+	})
+	if err == nil && !ok {
+		return nil, ErrNoSuchElement
+	}
+	return found, err
+}
+
+// Any returns true if at least one element satisfies the predicate.
+func Any(it Iterator, test PredicateFunc) (bool, error) {
+	_, err := Find(it, test)
+	if errors.Is(err, ErrNoSuchElement) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// All returns true if every element satisfies the predicate, true as well if the iterator has no elements.
+func All(it Iterator, test PredicateFunc) (bool, error) {
+	all := true
+	err := drain(it, func(index int, item interface{}) (bool, error) {
+		ok, err := test(item)
+		if err != nil {
+			return false, &StageError{Op: "All", Index: index, Err: err}
+		}
+		all = ok
+		return ok, nil
+	})
+	return all && err == nil, err
+}
+
+// None returns true if no element satisfies the predicate.
+func None(it Iterator, test PredicateFunc) (bool, error) {
+	found, err := Any(it, test)
+	return !found && err == nil, err
+}
+
+// ForEach calls the function on every element of the iterator, stopping at the first error.
+func ForEach(it Iterator, fn func(item interface{}) error) error {
+	return drain(it, func(index int, item interface{}) (bool, error) {
+		if err := fn(item); err != nil {
+			return false, &StageError{Op: "ForEach", Index: index, Err: err}
+		}
+		return true, nil
+	})
+}
+
+// drain feeds the elements of the iterator, with their index, to fn until it returns false.
+// The iterator is closed before returning.
+func drain(it Iterator, fn func(index int, item interface{}) (bool, error)) (err error) {
+	defer func() {
+		if closeErr := it.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+	}()
+	for index := 0; ; index++ {
+		next, ok, err := pull(it)
+		if err != nil || !ok {
+			return err
+		}
+		more, err := fn(index, next)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// An iterator over the items recording whether it was closed
+func closeTracking(items []Item) (Iterator, *bool) {
+	closed := false
+	return NewCloseableIterator(next(items), func() error {
+		closed = true
+		return nil
+	}), &closed
+}
+
+func isEven(item interface{}) (bool, error) {
+	return item.(*Item).ID%2 == 0, nil
+}
+
+func sumIds(acc interface{}, item interface{}) (interface{}, error) {
+	return acc.(int) + item.(*Item).ID, nil
+}
+
+func TestCollect(t *testing.T) {
+	iterator, closed := closeTracking(generateItems(0, 5))
+	items, err := Collect(iterator)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, ids(items...))
+	assert.True(t, *closed)
+}
+
+func TestCollectN(t *testing.T) {
+	items := generateItems(0, 5)
+	computeNext, idx := nextAndIndex(items)
+	collected, err := CollectN(NewDefaultIterator(computeNext), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2}, ids(collected...))
+	assert.Equal(t, 3, *idx)
+
+	collected, err = CollectN(Items(items).Iterator(), 0)
+	assert.Nil(t, err)
+	assert.Empty(t, collected)
+
+	collected, err = CollectN(Items(items).Iterator(), 10)
+	assert.Nil(t, err)
+	assert.Len(t, collected, 5)
+}
+
+func TestCount(t *testing.T) {
+	iterator, closed := closeTracking(generateItems(0, 5))
+	count, err := Count(Filter(iterator, isEven))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+	assert.True(t, *closed)
+}
+
+func TestReduce(t *testing.T) {
+	sum, err := Reduce(Transform(Items(generateItems(0, 10)).Iterator(), func(item interface{}) (interface{}, error) {
+		return item.(*Item).ID, nil
+	}), func(acc interface{}, item interface{}) (interface{}, error) {
+		return acc.(int) + item.(int), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 45, sum)
+
+	_, err = Reduce(Items(nil).Iterator(), sumIds)
+	assert.Equal(t, ErrNoSuchElement, err)
+}
+
+func TestFold(t *testing.T) {
+	sum, err := Fold(Items(generateItems(0, 10)).Iterator(), 100, sumIds)
+	assert.Nil(t, err)
+	assert.Equal(t, 145, sum)
+
+	sum, err = Fold(Items(nil).Iterator(), 100, sumIds)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, sum)
+}
+
+func TestFirstLast(t *testing.T) {
+	iterator, closed := closeTracking(generateItems(3, 6))
+	first, err := First(iterator)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, first.(*Item).ID)
+	assert.True(t, *closed)
+
+	last, err := Last(Items(generateItems(3, 6)).Iterator())
+	assert.Nil(t, err)
+	assert.Equal(t, 5, last.(*Item).ID)
+
+	_, err = First(Items(nil).Iterator())
+	assert.Equal(t, ErrNoSuchElement, err)
+	_, err = Last(Items(nil).Iterator())
+	assert.Equal(t, ErrNoSuchElement, err)
+}
+
+func TestFind(t *testing.T) {
+	found, err := Find(Items(itemsFromIds(1, 3, 4, 6)).Iterator(), isEven)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, found.(*Item).ID)
+
+	_, err = Find(Items(itemsFromIds(1, 3)).Iterator(), isEven)
+	assert.Equal(t, ErrNoSuchElement, err)
+}
+
+func TestAnyAllNone(t *testing.T) {
+	tests := []struct {
+		ids            []int
+		any, all, none bool
+	}{
+		{nil, false, true, true},
+		{[]int{1, 3}, false, false, true},
+		{[]int{1, 2}, true, false, false},
+		{[]int{2, 4}, true, true, false},
+	}
+	for _, test := range tests {
+		any, err := Any(Items(itemsFromIds(test.ids...)).Iterator(), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, test.any, any, "any %v", test.ids)
+
+		all, err := All(Items(itemsFromIds(test.ids...)).Iterator(), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, test.all, all, "all %v", test.ids)
+
+		none, err := None(Items(itemsFromIds(test.ids...)).Iterator(), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, test.none, none, "none %v", test.ids)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	total := 0
+	err := ForEach(Items(generateItems(0, 10)).Iterator(), func(item interface{}) error {
+		total += item.(*Item).ID
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 45, total)
+}
+
+func TestReducers_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+
+	_, err := Collect(failingIterator(generateItems(0, 3), failure))
+	assert.Equal(t, failure, err)
+	_, err = Count(failingIterator(generateItems(0, 3), failure))
+	assert.Equal(t, failure, err)
+	_, err = Last(failingIterator(generateItems(0, 3), failure))
+	assert.Equal(t, failure, err)
+	_, err = All(failingIterator(generateItems(0, 3), failure), func(item interface{}) (bool, error) {
+		return true, nil
+	})
+	assert.Equal(t, failure, err)
+
+	iterator, closed := closeTracking(generateItems(0, 3))
+	err = ForEach(iterator, func(item interface{}) error {
+		return failure
+	})
+	assert.True(t, errors.Is(err, failure))
+	assert.True(t, *closed)
+
+	closeFailure := errors.New("close failure")
+	err = ForEach(NewCloseableIterator(next(nil), func() error {
+		return closeFailure
+	}), func(item interface{}) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, closeFailure))
+}
@@ -0,0 +1,57 @@
+package iterator
+
+import "iter"
+
+// ToSeq exposes the iterator as a range-over-func sequence:
+//
+//	for item, err := range iterator.ToSeq(it) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+//
+// An error computing the next element is yielded as the last pair of the loop.
+// The iterator is closed when the loop ends, including when it breaks early.
+func ToSeq(it Iterator) iter.Seq2[interface{}, error] {
+	return func(yield func(interface{}, error) bool) {
+		for it.HasNext() {
+			next, err := it.Next()
+			if !yield(next, err) || err != nil {
+				_ = it.Close()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			_ = it.Close()
+			yield(nil, err)
+			return
+		}
+		if err := it.Close(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// FromSeq returns an iterator over a range-over-func sequence.
+// A non nil error yielded by the sequence fails the iterator, closing the iterator stops the sequence.
+func FromSeq(seq iter.Seq2[interface{}, error]) Iterator {
+	return FromPull(iter.Pull2(seq))
+}
+
+// FromPull returns an iterator over the next and stop functions returned by iter.Pull2.
+// Closing the iterator calls stop.
+func FromPull(next func() (interface{}, error, bool), stop func()) Iterator {
+	return NewCloseableIterator(func() (interface{}, bool, error) {
+		item, err, ok := next()
+		if !ok {
+			return nil, true, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return item, false, nil
+	}, func() error {
+		stop()
+		return nil
+	})
+}
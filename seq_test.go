@@ -0,0 +1,103 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSeq(t *testing.T) {
+	items := generateItems(0, 10)
+	total := 0
+	for next, err := range ToSeq(Items(items).Iterator()) {
+		assert.Nil(t, err)
+		total += next.(*Item).ID
+	}
+	assert.Equal(t, 45, total)
+}
+
+func TestToSeq_ClosesOnBreak(t *testing.T) {
+	items := generateItems(0, 10)
+	computeNext, idx := nextAndIndex(items)
+	iterator := NewCloseableIterator(computeNext, func() error {
+		*idx = -1
+		return nil
+	})
+
+	for next := range ToSeq(iterator) {
+		if next.(*Item).ID == 3 {
+			break
+		}
+	}
+	assert.Equal(t, -1, *idx)
+}
+
+func TestToSeq_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Transform(Items(generateItems(0, 10)).Iterator(), func(item interface{}) (interface{}, error) {
+		if item.(*Item).ID == 2 {
+			return nil, failure
+		}
+		return item, nil
+	})
+
+	i := 0
+	var last error
+	for _, err := range ToSeq(iterator) {
+		last = err
+		i++
+	}
+	assert.Equal(t, 3, i)
+	assert.True(t, errors.Is(last, failure))
+}
+
+func TestFromSeq(t *testing.T) {
+	seq := func(yield func(interface{}, error) bool) {
+		for i := 0; i < 5; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+
+	iterator := FromSeq(seq)
+	i := 0
+	for iterator.HasNext() {
+		next, err := iterator.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, i, next)
+		i++
+	}
+	assert.Equal(t, 5, i)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestFromSeq_StopsOnClose(t *testing.T) {
+	stopped := false
+	seq := func(yield func(interface{}, error) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+
+	iterator := Limit(FromSeq(seq), 3)
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+	}
+	assert.Nil(t, iterator.Close())
+	assert.True(t, stopped)
+}
+
+func TestFromSeq_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := FromSeq(func(yield func(interface{}, error) bool) {
+		yield(nil, failure)
+	})
+	_, err := iterator.Next()
+	assert.Equal(t, failure, err)
+	assert.Nil(t, iterator.Close())
+}
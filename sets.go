@@ -0,0 +1,116 @@
+package iterator
+
+// Set operations over sorted iterators. The inputs must be sorted according to the compare function, elements
+// comparing equal being the same set member: duplicates within an input are returned once.
+// They walk both inputs in lockstep, holding a single element of each in memory.
+
+// Union returns the elements found in either iterator.
+func Union(a, b Iterator, compareFn CompareFunc) Iterator {
+	return setOperation(a, b, compareFn, true, true, true)
+}
+
+// Intersect returns the elements found in both iterators, as returned by the first one.
+func Intersect(a, b Iterator, compareFn CompareFunc) Iterator {
+	return setOperation(a, b, compareFn, false, true, false)
+}
+
+// Difference returns the elements of the first iterator not found in the second one.
+func Difference(a, b Iterator, compareFn CompareFunc) Iterator {
+	return setOperation(a, b, compareFn, true, false, false)
+}
+
+// SymmetricDifference returns the elements found in only one of the iterators.
+func SymmetricDifference(a, b Iterator, compareFn CompareFunc) Iterator {
+	return setOperation(a, b, compareFn, true, false, true)
+}
+
+// setOperation merges the sorted iterators keeping the elements found only in a, in both, or only in b.
+// Elements found in both are returned as found in a.
+func setOperation(a, b Iterator, compareFn CompareFunc, onlyA, both, onlyB bool) Iterator {
+	equals := func(item1 interface{}, item2 interface{}) bool {
+		return compareFn(item1, item2) == 0
+	}
+	sa, sb := &lookahead{it: Dedup(a, equals)}, &lookahead{it: Dedup(b, equals)}
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			for {
+				okA, err := sa.head()
+				if err != nil {
+					return nil, true, err
+				}
+				// nothing else can be returned, avoids reading the other iterator
+				if !okA && !onlyB {
+					return nil, true, nil
+				}
+				okB, err := sb.head()
+				if err != nil {
+					return nil, true, err
+				}
+				if !okB && !onlyA {
+					return nil, true, nil
+				}
+
+				var c int
+				switch {
+				case !okA && !okB:
+					return nil, true, nil
+				case !okA:
+					c = 1
+				case !okB:
+					c = -1
+				default:
+					c = compareFn(sa.item, sb.item)
+				}
+
+				switch {
+				case c < 0:
+					if item := sa.take(); onlyA {
+						return item, false, nil
+					}
+				case c > 0:
+					if item := sb.take(); onlyB {
+						return item, false, nil
+					}
+				default:
+					item := sa.take()
+					sb.take()
+					if both {
+						return item, false, nil
+					}
+				}
+			}
+		},
+		closer: func() error {
+			return closeAll([]Iterator{a, b})
+		},
+		sources: []Iterator{a, b},
+	}
+}
+
+// An iterator with its head element read ahead
+type lookahead struct {
+	it      Iterator
+	item    interface{}
+	hasItem bool
+	done    bool
+}
+
+// head reads the head element, if not already read, returning false if there is none.
+func (s *lookahead) head() (bool, error) {
+	if s.hasItem || s.done {
+		return s.hasItem, nil
+	}
+	next, ok, err := pull(s.it)
+	if err != nil {
+		return false, err
+	}
+	s.item, s.hasItem, s.done = next, ok, !ok
+	return ok, nil
+}
+
+// take consumes the head element.
+func (s *lookahead) take() interface{} {
+	item := s.item
+	s.item, s.hasItem = nil, false
+	return item
+}
@@ -0,0 +1,63 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation func(a, b Iterator, compareFn CompareFunc) Iterator
+		expected  []int
+	}{
+		{"Union", Union, []int{1, 2, 3, 4, 5, 6, 8}},
+		{"Intersect", Intersect, []int{2, 4}},
+		{"Difference", Difference, []int{1, 3}},
+		{"SymmetricDifference", SymmetricDifference, []int{1, 3, 5, 6, 8}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			closed := 0
+			closer := func() error {
+				closed++
+				return nil
+			}
+			a := NewCloseableIterator(next(itemsFromIds(1, 2, 2, 3, 4)), closer)
+			b := NewCloseableIterator(next(itemsFromIds(2, 4, 4, 5, 6, 8)), closer)
+
+			items, err := Collect(test.operation(a, b, compareIds))
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, ids(items...))
+			assert.Equal(t, 2, closed)
+		})
+	}
+}
+
+func TestSetOperations_Empty(t *testing.T) {
+	items, err := Collect(Union(Items(nil).Iterator(), Items(itemsFromIds(1, 2)).Iterator(), compareIds))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, ids(items...))
+
+	items, err = Collect(Intersect(Items(nil).Iterator(), Items(itemsFromIds(1, 2)).Iterator(), compareIds))
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestIntersect_StopsReading(t *testing.T) {
+	computeNext, idx := nextAndIndex(generateItems(0, 100))
+	items, err := Collect(Intersect(Items(itemsFromIds(1, 3)).Iterator(), NewDefaultIterator(computeNext), compareIds))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 3}, ids(items...))
+	// stops reading at the last match
+	assert.Equal(t, 4, *idx)
+}
+
+func TestSetOperations_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	items, err := Collect(Union(Items(itemsFromIds(1, 5)).Iterator(), failingIterator(itemsFromIds(2, 3), failure), compareIds))
+	assert.Equal(t, []int{1, 2, 3}, ids(items...))
+	assert.Equal(t, failure, err)
+}
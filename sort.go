@@ -0,0 +1,194 @@
+package iterator
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sort"
+)
+
+// Encoder writes the elements of a sorted run spilled to disk.
+type Encoder interface {
+	Encode(item interface{}) error
+}
+
+// Decoder reads back the elements written by an Encoder, returning io.EOF after the last one.
+type Decoder interface {
+	Decode() (interface{}, error)
+}
+
+// Codec creates the encoders and decoders of the sorted runs spilled to disk by Sort.
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// GobCodec encodes the elements with encoding/gob.
+// As they are encoded as interface values, their concrete types must be registered with gob.Register.
+type GobCodec struct{}
+
+type gobEncoder struct{ *gob.Encoder }
+
+type gobDecoder struct{ *gob.Decoder }
+
+func (GobCodec) NewEncoder(w io.Writer) Encoder {
+	return gobEncoder{gob.NewEncoder(w)}
+}
+
+func (GobCodec) NewDecoder(r io.Reader) Decoder {
+	return gobDecoder{gob.NewDecoder(r)}
+}
+
+func (e gobEncoder) Encode(item interface{}) error {
+	return e.Encoder.Encode(&item)
+}
+
+func (d gobDecoder) Decode() (interface{}, error) {
+	var item interface{}
+	err := d.Decoder.Decode(&item)
+	return item, err
+}
+
+type SortOptions struct {
+	// Maximum number of elements sorted in memory, when exceeded the sorted elements are spilled to a temporary file.
+	// Zero means no limit.
+	MaxInMemory int
+	// Directory of the temporary files, the default directory for temporary files if empty.
+	TempDir string
+	// Codec of the elements spilled to disk, GobCodec if nil.
+	Codec Codec
+}
+
+// Sort returns an iterator over the elements of the original, sorted according to the compare function.
+// Equal elements keep their original order. The original is read in full on the first call to HasNext or Next,
+// sorting up to opts.MaxInMemory elements at a time and spilling each sorted run to a temporary file;
+// the runs are then merged lazily. Closing the iterator deletes the temporary files.
+func Sort(it Iterator, compareFn CompareFunc, opts SortOptions) Iterator {
+	codec := opts.Codec
+	if codec == nil {
+		codec = GobCodec{}
+	}
+	var files []string
+	var sorted Iterator
+
+	// sorts the elements and writes them to a new temporary file
+	spill := func(items []interface{}) (err error) {
+		file, err := os.CreateTemp(opts.TempDir, "iterator-sort-*")
+		if err != nil {
+			return err
+		}
+		files = append(files, file.Name())
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+
+		sortStable(items, compareFn)
+		w := bufio.NewWriter(file)
+		encoder := codec.NewEncoder(w)
+		for _, item := range items {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}
+
+	// reads back a sorted run
+	run := func(name string) (Iterator, error) {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		decoder := codec.NewDecoder(bufio.NewReader(file))
+		return NewCloseableIterator(func() (interface{}, bool, error) {
+			item, err := decoder.Decode()
+			if err == io.EOF {
+				return nil, true, nil
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			return item, false, nil
+		}, file.Close), nil
+	}
+
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if sorted == nil {
+				var items []interface{}
+				for {
+					next, ok, err := pull(it)
+					if err != nil {
+						return nil, true, err
+					}
+					if !ok {
+						break
+					}
+					items = append(items, next)
+					if len(items) == opts.MaxInMemory {
+						if err := spill(items); err != nil {
+							return nil, true, err
+						}
+						items = items[:0]
+					}
+				}
+				if err := it.Close(); err != nil {
+					return nil, true, err
+				}
+
+				// the last run stays in memory
+				sortStable(items, compareFn)
+				runs := make([]Iterator, 0, len(files)+1)
+				for _, name := range files {
+					r, err := run(name)
+					if err != nil {
+						_ = closeAll(runs)
+						return nil, true, err
+					}
+					runs = append(runs, r)
+				}
+				sorted = Merge(compareFn, append(runs, fromSlice(items))...)
+			}
+			next, ok, err := pull(sorted)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			return next, false, nil
+		},
+		closer: func() error {
+			var errs []error
+			if sorted != nil {
+				errs = append(errs, sorted.Close())
+			}
+			errs = append(errs, it.Close())
+			for _, name := range files {
+				errs = append(errs, os.Remove(name))
+			}
+			return errors.Join(errs...)
+		},
+		sources: []Iterator{it},
+	}
+}
+
+func sortStable(items []interface{}, compareFn CompareFunc) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return compareFn(items[i], items[j]) < 0
+	})
+}
+
+// fromSlice returns an iterator over the elements of the slice.
+func fromSlice(items []interface{}) Iterator {
+	i := 0
+	return NewDefaultIterator(func() (interface{}, bool, error) {
+		if i >= len(items) {
+			return nil, true, nil
+		}
+		next := items[i]
+		i++
+		return next, false, nil
+	})
+}
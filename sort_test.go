@@ -0,0 +1,100 @@
+package iterator
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gob.Register(&Item{})
+}
+
+func compareIds(item1 interface{}, item2 interface{}) int {
+	return item1.(*Item).ID - item2.(*Item).ID
+}
+
+func shuffledItems(n int) []Item {
+	items := make([]Item, n)
+	for i, v := range rand.New(rand.NewSource(1)).Perm(n) {
+		// pairs of items share the same id, the name records the original position
+		items[i] = Item{ID: v / 2, Name: fmt.Sprintf("%04d", i)}
+	}
+	return items
+}
+
+func assertSorted(t *testing.T, items []interface{}, n int) {
+	assert.Len(t, items, n)
+	for i := 1; i < len(items); i++ {
+		prev, cur := items[i-1].(*Item), items[i].(*Item)
+		assert.True(t, prev.ID <= cur.ID)
+		if prev.ID == cur.ID {
+			// stable
+			assert.True(t, prev.Name < cur.Name)
+		}
+	}
+}
+
+func TestSort_InMemory(t *testing.T) {
+	items, err := Collect(Sort(Items(shuffledItems(100)).Iterator(), compareIds, SortOptions{}))
+	assert.Nil(t, err)
+	assertSorted(t, items, 100)
+}
+
+func TestSort_SpillsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	iterator := Sort(Items(shuffledItems(1000)).Iterator(), compareIds, SortOptions{
+		MaxInMemory: 64,
+		TempDir:     dir,
+	})
+
+	first, err := iterator.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, first.(*Item).ID)
+	files, _ := os.ReadDir(dir)
+	assert.Len(t, files, 15)
+
+	rest, err := Collect(iterator)
+	assert.Nil(t, err)
+	assertSorted(t, append([]interface{}{first}, rest...), 1000)
+
+	files, _ = os.ReadDir(dir)
+	assert.Empty(t, files)
+}
+
+func TestSort_CloseEarly(t *testing.T) {
+	dir := t.TempDir()
+	iterator := Sort(Items(shuffledItems(100)).Iterator(), compareIds, SortOptions{
+		MaxInMemory: 10,
+		TempDir:     dir,
+	})
+	assert.True(t, iterator.HasNext())
+	assert.Nil(t, iterator.Close())
+
+	files, _ := os.ReadDir(dir)
+	assert.Empty(t, files)
+}
+
+func TestSort_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	dir := t.TempDir()
+	_, err := Collect(Sort(failingIterator(shuffledItems(100), failure), compareIds, SortOptions{
+		MaxInMemory: 10,
+		TempDir:     dir,
+	}))
+	assert.Equal(t, failure, err)
+	files, _ := os.ReadDir(dir)
+	assert.Empty(t, files)
+
+	// not registered with gob
+	type unregistered struct{ ID int }
+	_, err = Collect(Sort(fromSlice([]interface{}{unregistered{2}, unregistered{1}}), func(item1 interface{}, item2 interface{}) int {
+		return item1.(unregistered).ID - item2.(unregistered).ID
+	}, SortOptions{MaxInMemory: 1, TempDir: dir}))
+	assert.NotNil(t, err)
+}
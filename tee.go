@@ -0,0 +1,168 @@
+package iterator
+
+import "sync"
+
+// OverflowPolicy tells what a bounded buffer does when it is full.
+type OverflowPolicy int
+
+const (
+	// Wait for the buffer to have room, blocking the caller
+	BlockOnOverflow OverflowPolicy = iota
+	// Fail with ErrBufferFull
+	FailOnOverflow
+)
+
+type TeeOptions struct {
+	// Maximum number of elements buffered between the slowest and the fastest branch, zero means no limit.
+	MaxBuffer int
+	// What a branch reading a new element from the original does when MaxBuffer is reached.
+	// Blocking waits for the slowest branch to advance, which must then be consumed on another goroutine.
+	// Failing ends the fast branch with ErrBufferFull, letting the others continue.
+	OnOverflow OverflowPolicy
+}
+
+// Tee splits the iterator into n independent iterators (branches) returning the same elements.
+// The elements are buffered from the slowest branch to the fastest one, see TeeWithOptions to bound the buffer.
+// Branches can be consumed on different goroutines. The original iterator is closed once all branches are closed.
+func Tee(it Iterator, n int) []Iterator {
+	return TeeWithOptions(it, n, TeeOptions{})
+}
+
+// TeeWithOptions is like Tee with the given options.
+func TeeWithOptions(it Iterator, n int, opts TeeOptions) []Iterator {
+	if n < 1 {
+		panic("iterator: number of branches must be positive")
+	}
+	t := newTee(it, n, opts)
+	branches := make([]Iterator, n)
+	for i := range branches {
+		branches[i] = t.branch(i)
+	}
+	return branches
+}
+
+// The state shared by the branches of a Tee
+type tee struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	source Iterator
+	// the elements read from the source and not yet returned by every branch, buffer[0] being at position base
+	buffer []result
+	base   int
+	// the position of the next element of every branch, -1 once closed
+	positions []int
+	open      int
+	// the source has no more elements
+	done bool
+	// a branch is reading from the source
+	pulling bool
+
+	opts TeeOptions
+}
+
+func newTee(it Iterator, n int, opts TeeOptions) *tee {
+	t := &tee{
+		source:    it,
+		positions: make([]int, n),
+		open:      n,
+		opts:      opts,
+	}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+func (t *tee) branch(i int) Iterator {
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			return t.next(i)
+		},
+		closer: func() error {
+			return t.close(i)
+		},
+		sources: []Iterator{t.source},
+	}
+}
+
+func (t *tee) next(branch int) (interface{}, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for {
+		pos := t.positions[branch]
+		if pos < t.base+len(t.buffer) {
+			r := t.buffer[pos-t.base]
+			t.positions[branch]++
+			t.trim()
+			if r.err != nil {
+				return nil, false, r.err
+			}
+			return r.item, false, nil
+		}
+		if t.done {
+			return nil, true, nil
+		}
+
+		if t.opts.MaxBuffer > 0 && len(t.buffer) >= t.opts.MaxBuffer {
+			if t.opts.OnOverflow == FailOnOverflow {
+				// the failed branch is over, it no longer holds elements in the buffer
+				t.positions[branch] = -1
+				t.trim()
+				return nil, false, ErrBufferFull
+			}
+			t.cond.Wait()
+			continue
+		}
+		if t.pulling {
+			t.cond.Wait()
+			continue
+		}
+
+		// reads from the source without holding the lock, the other branches can still read the buffer
+		t.pulling = true
+		t.mu.Unlock()
+		next, ok, err := pull(t.source)
+		t.mu.Lock()
+		t.pulling = false
+		if ok || err != nil {
+			t.buffer = append(t.buffer, result{item: next, err: err})
+		}
+		t.done = !ok
+		t.cond.Broadcast()
+	}
+}
+
+// trim drops the elements returned by every open branch.
+func (t *tee) trim() {
+	min := -1
+	for _, pos := range t.positions {
+		if pos >= 0 && (min < 0 || pos < min) {
+			min = pos
+		}
+	}
+	if min < 0 {
+		min = t.base + len(t.buffer)
+	}
+	if drop := min - t.base; drop > 0 {
+		for i := 0; i < drop; i++ {
+			t.buffer[i] = result{}
+		}
+		t.buffer = t.buffer[drop:]
+		t.base = min
+		t.cond.Broadcast()
+	}
+}
+
+func (t *tee) close(branch int) error {
+	t.mu.Lock()
+	t.positions[branch] = -1
+	t.open--
+	t.trim()
+	open := t.open
+	t.cond.Broadcast()
+	t.mu.Unlock()
+
+	if open == 0 {
+		return t.source.Close()
+	}
+	return nil
+}
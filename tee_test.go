@@ -0,0 +1,96 @@
+package iterator
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTee(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 10))
+	branches := Tee(source, 2)
+
+	first, err := Collect(Limit(branches[0], 3))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2}, ids(first...))
+	assert.False(t, *closed)
+
+	second, err := Collect(branches[1])
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ids(second...))
+	assert.True(t, *closed)
+}
+
+func TestTee_BuffersBetweenSlowestAndFastest(t *testing.T) {
+	shared := newTee(Items(generateItems(0, 10)).Iterator(), 3, TeeOptions{})
+	branches := []Iterator{shared.branch(0), shared.branch(1), shared.branch(2)}
+
+	for i := 0; i < 4; i++ {
+		_, err := branches[0].Next()
+		assert.Nil(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		_, err := branches[1].Next()
+		assert.Nil(t, err)
+	}
+	// branch 2 hasn't started yet, everything read is kept
+	assert.Len(t, shared.buffer, 4)
+
+	assert.Nil(t, branches[2].Close())
+	assert.Len(t, shared.buffer, 2)
+	assert.Equal(t, 2, shared.base)
+}
+
+func TestTee_FailOnOverflow(t *testing.T) {
+	branches := TeeWithOptions(Items(generateItems(0, 10)).Iterator(), 2, TeeOptions{
+		MaxBuffer:  2,
+		OnOverflow: FailOnOverflow,
+	})
+
+	_, _ = branches[0].Next()
+	_, _ = branches[0].Next()
+	_, err := branches[0].Next()
+	assert.Equal(t, ErrBufferFull, err)
+
+	second, err := Collect(branches[1])
+	assert.Nil(t, err)
+	assert.Len(t, second, 10)
+}
+
+func TestTee_BlockOnOverflow(t *testing.T) {
+	source, closed := closeTracking(generateItems(0, 1000))
+	branches := TeeWithOptions(source, 3, TeeOptions{MaxBuffer: 5})
+
+	var wg sync.WaitGroup
+	results := make([][]interface{}, len(branches))
+	for i, branch := range branches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := Collect(branch)
+			assert.Nil(t, err)
+			results[i] = items
+		}()
+	}
+	wg.Wait()
+
+	for _, items := range results {
+		assert.Len(t, items, 1000)
+		for i, item := range items {
+			assert.Equal(t, i, item.(*Item).ID)
+		}
+	}
+	assert.True(t, *closed)
+}
+
+func TestTee_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	branches := Tee(failingIterator(generateItems(0, 3), failure), 2)
+	for _, branch := range branches {
+		items, err := Collect(branch)
+		assert.Len(t, items, 3)
+		assert.Equal(t, failure, err)
+	}
+}
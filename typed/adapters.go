@@ -0,0 +1,51 @@
+package typed
+
+import (
+	"fmt"
+	"reflect"
+
+	iterator "github.com/calvernaz/go-iterators"
+)
+
+// FromUntyped wraps an interface{} based iterator exposing its elements as T.
+// An element that is neither a T nor nil makes the typed iterator fail; nil elements are returned as the zero T.
+func FromUntyped[T any](it iterator.Iterator) Iterator[T] {
+	index := 0
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			if !it.HasNext() {
+				return zero, true, it.Err()
+			}
+			next, err := it.Next()
+			if err != nil {
+				return zero, false, err
+			}
+			index++
+			if next == nil {
+				return zero, false, nil
+			}
+			item, ok := next.(T)
+			if !ok {
+				err := fmt.Errorf("unexpected element of type %T, want %s", next, reflect.TypeOf((*T)(nil)).Elem())
+				return zero, false, &iterator.StageError{Op: "FromUntyped", Index: index - 1, Err: err}
+			}
+			return item, false, nil
+		},
+		closer: it.Close,
+	}
+}
+
+// Untyped wraps a typed iterator so that it can be used with the interface{} based API.
+func Untyped[T any](it Iterator[T]) iterator.Iterator {
+	return iterator.NewCloseableIterator(func() (interface{}, bool, error) {
+		if !it.HasNext() {
+			return nil, true, it.Err()
+		}
+		next, err := it.Next()
+		if err != nil {
+			return nil, false, err
+		}
+		return next, false, nil
+	}, it.Close)
+}
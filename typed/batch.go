@@ -0,0 +1,34 @@
+package typed
+
+// Batch returns an iterator over slices of up to size consecutive elements of the original.
+// Only the last batch can be smaller. An error is reported after the batch of the elements preceding it.
+func Batch[T any](it Iterator[T], size int) Iterator[[]T] {
+	if size < 1 {
+		panic("typed: batch size must be positive")
+	}
+	var failure error
+	return &DefaultIterator[[]T]{
+		ComputeNext: func() ([]T, bool, error) {
+			if failure != nil {
+				return nil, true, failure
+			}
+			var batch []T
+			for len(batch) < size {
+				next, ok, err := pull(it)
+				if err != nil {
+					failure = err
+					break
+				}
+				if !ok {
+					break
+				}
+				batch = append(batch, next)
+			}
+			if len(batch) == 0 {
+				return nil, true, failure
+			}
+			return batch, false, nil
+		},
+		closer: it.Close,
+	}
+}
@@ -0,0 +1,166 @@
+// Package typed offers the iterator pattern with type parameters.
+// It mirrors the interface{} based API of the parent package, removing the need to cast every element
+// returned by Next, and provides adapters to move between the two while migrating.
+
+package typed
+
+import (
+	"io"
+
+	iterator "github.com/calvernaz/go-iterators"
+)
+
+// If there is a next element return: next, false, nil
+// If an error occurs computing the next element return: zero, false, error
+// If there is no next element return: zero, true, nil
+type ComputeNext[T any] func() (next T, eod bool, err error)
+type Closer func() error
+
+// An iterator over a stream of data of type T
+type Iterator[T any] interface {
+	HasNext() bool
+	Next() (T, error)
+	Peek() (item T, e error)
+	// Err returns the error that stopped the iteration, nil if the end of data was reached.
+	// Check it once HasNext returns false.
+	Err() error
+	io.Closer
+}
+
+var _ Iterator[int] = (*DefaultIterator[int])(nil)
+
+type DefaultIterator[T any] struct {
+	state  iterator.State
+	next   T
+	err    error
+	closed bool
+
+	ComputeNext ComputeNext[T]
+
+	closer Closer
+}
+
+// Given a way to compute next, returns an iterator
+func NewDefaultIterator[T any](computeNext ComputeNext[T]) Iterator[T] {
+	return &DefaultIterator[T]{
+		ComputeNext: computeNext,
+	}
+}
+
+// Given a way to compute next and a close handler, return a closeable iterator
+func NewCloseableIterator[T any](computeNext ComputeNext[T], closer Closer) Iterator[T] {
+	return &DefaultIterator[T]{
+		ComputeNext: computeNext,
+		closer:      closer,
+	}
+}
+
+// Returns true if the iterator can be continued or false if the end of data has been reached.
+func (it *DefaultIterator[T]) HasNext() bool {
+	switch it.state {
+	case iterator.Ready:
+		return true
+	case iterator.Done, iterator.Failed:
+		return false
+	}
+	return it.tryToComputeNext()
+}
+
+// Returns the next item in the iteration.
+// This method should be always called in combination with the HasNext.
+// If the iterator reached the end of data, the method will return iterator.ErrNoSuchElement,
+// iterator.ErrClosed if it was closed.
+func (it *DefaultIterator[T]) Next() (T, error) {
+	var zero T
+	hasNext := it.HasNext()
+	if it.err != nil {
+		return zero, it.err
+	}
+	if !hasNext {
+		return zero, it.exhausted()
+	}
+
+	it.state = iterator.NotReady
+	nextItem := it.next
+	it.next = zero
+	return nextItem, nil
+}
+
+func (it *DefaultIterator[T]) tryToComputeNext() bool {
+	it.state = iterator.Failed // temporary pessimism
+
+	next, eod, err := it.ComputeNext()
+	if err != nil {
+		it.state = iterator.Failed
+		it.err = err
+		return false
+	}
+
+	if eod {
+		it.state = iterator.Done
+		return false
+	}
+
+	it.state = iterator.Ready
+	it.next = next
+	return true
+}
+
+// Returns the next element without continuing the iteration.
+func (it *DefaultIterator[T]) Peek() (T, error) {
+	var zero T
+	hasNext := it.HasNext()
+	if it.err != nil {
+		return zero, it.err
+	}
+	if !hasNext {
+		return zero, it.exhausted()
+	}
+	return it.next, nil
+}
+
+// Returns the error that stopped the iteration, nil if the end of data was reached or the iteration is ongoing.
+func (it *DefaultIterator[T]) Err() error {
+	return it.err
+}
+
+// Returns the current state of the iterator.
+func (it *DefaultIterator[T]) State() iterator.State {
+	return it.state
+}
+
+// Closes the iterator calling the close handler, if any. Closing an iterator more than once has no effect.
+func (it *DefaultIterator[T]) Close() error {
+	if it.closed {
+		return nil
+	}
+	var zero T
+	it.closed = true
+	it.state = iterator.Done
+	it.next = zero
+	if it.closer != nil {
+		return it.closer()
+	}
+	return nil
+}
+
+func (it *DefaultIterator[T]) exhausted() error {
+	if it.closed {
+		return iterator.ErrClosed
+	}
+	return iterator.ErrNoSuchElement
+}
+
+// FromSlice returns an iterator over the elements of the slice.
+func FromSlice[T any](items []T) Iterator[T] {
+	i := 0
+	return NewDefaultIterator(func() (T, bool, error) {
+		var zero T
+		if i >= len(items) {
+			return zero, true, nil
+		}
+		next := items[i]
+		i++
+		return next, false, nil
+	})
+}
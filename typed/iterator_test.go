@@ -0,0 +1,210 @@
+package typed
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	iterator "github.com/calvernaz/go-iterators"
+	"github.com/stretchr/testify/assert"
+)
+
+func collect[T any](t *testing.T, it Iterator[T]) []T {
+	var items []T
+	for it.HasNext() {
+		next, err := it.Next()
+		assert.Nil(t, err)
+		items = append(items, next)
+	}
+	assert.Nil(t, it.Close())
+	return items
+}
+
+func ints(from, to int) []int {
+	var items []int
+	for i := from; i < to; i++ {
+		items = append(items, i)
+	}
+	return items
+}
+
+func compareInts(a, b int) int {
+	return a - b
+}
+
+func TestSimpleIterator(t *testing.T) {
+	it := FromSlice(ints(0, 10))
+	total := 0
+	for it.HasNext() {
+		next, err := it.Next()
+		assert.Nil(t, err)
+		total += next
+	}
+	assert.Equal(t, 45, total)
+
+	_, err := it.Next()
+	assert.NotNil(t, err)
+}
+
+func TestCloseHandler(t *testing.T) {
+	closed := false
+	it := NewCloseableIterator(func() (int, bool, error) {
+		return 0, true, nil
+	}, func() error {
+		closed = true
+		return nil
+	})
+	assert.False(t, it.HasNext())
+	assert.Nil(t, it.Close())
+	assert.True(t, closed)
+}
+
+func TestPeek(t *testing.T) {
+	it := FromSlice([]string{"a", "b"})
+	peek, err := it.Peek()
+	assert.Nil(t, err)
+	assert.Equal(t, "a", peek)
+
+	next, err := it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "a", next)
+}
+
+func TestFilter(t *testing.T) {
+	it := Filter(FromSlice(ints(0, 10)), func(item int) (bool, error) {
+		return item%2 == 0, nil
+	})
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, collect(t, it))
+}
+
+func TestTransform(t *testing.T) {
+	it := Transform(FromSlice(ints(0, 3)), func(item int) (string, error) {
+		return strconv.Itoa(item), nil
+	})
+	assert.Equal(t, []string{"0", "1", "2"}, collect(t, it))
+}
+
+func TestTransform_WhenErrorOccurs(t *testing.T) {
+	it := Transform(FromSlice(ints(0, 3)), func(item int) (string, error) {
+		return "", fmt.Errorf("failed transforming value: %d", item)
+	})
+	_, err := it.Next()
+	assert.NotNil(t, err)
+	assert.False(t, it.HasNext())
+}
+
+func TestSkip(t *testing.T) {
+	it := Skip(FromSlice(ints(0, 10)), 7)
+	assert.Equal(t, []int{7, 8, 9}, collect(t, it))
+}
+
+func TestLimit(t *testing.T) {
+	it := Limit(FromSlice(ints(0, 10)), 3)
+	assert.Equal(t, []int{0, 1, 2}, collect(t, it))
+}
+
+func TestConcat(t *testing.T) {
+	it := Concat(FromSlice(ints(0, 3)), FromSlice(ints(3, 5)))
+	assert.Equal(t, ints(0, 5), collect(t, it))
+}
+
+func TestMerge(t *testing.T) {
+	it := Merge(compareInts,
+		FromSlice([]int{3, 6, 11, 12}),
+		FromSlice([]int{2, 4, 7, 9}),
+		FromSlice([]int{0, 1, 5, 8, 10}))
+	assert.Equal(t, ints(0, 13), collect(t, it))
+}
+
+func TestDedup(t *testing.T) {
+	it := Dedup(FromSlice([]int{0, 0, 1, 2, 2, 2, 3}), func(a, b int) bool {
+		return a == b
+	})
+	assert.Equal(t, []int{0, 1, 2, 3}, collect(t, it))
+}
+
+func TestFromUntyped(t *testing.T) {
+	untyped := iterator.NewDefaultIterator(func() func() (interface{}, bool, error) {
+		items := []interface{}{1, nil, 3}
+		return func() (interface{}, bool, error) {
+			if len(items) == 0 {
+				return nil, true, nil
+			}
+			next := items[0]
+			items = items[1:]
+			return next, false, nil
+		}
+	}())
+	assert.Equal(t, []int{1, 0, 3}, collect(t, FromUntyped[int](untyped)))
+}
+
+func TestFromUntyped_WrongType(t *testing.T) {
+	untyped := Untyped(FromSlice([]string{"a"}))
+	_, err := FromUntyped[int](untyped).Next()
+	assert.NotNil(t, err)
+}
+
+func TestUntyped(t *testing.T) {
+	it := iterator.Limit(Untyped(FromSlice(ints(0, 10))), 2)
+	var items []interface{}
+	for it.HasNext() {
+		next, err := it.Next()
+		assert.Nil(t, err)
+		items = append(items, next)
+	}
+	assert.Equal(t, []interface{}{0, 1}, items)
+}
+
+func TestUntyped_PropagatesErrors(t *testing.T) {
+	failure := errors.New("failure")
+	it := Untyped(NewDefaultIterator(func() (int, bool, error) {
+		return 0, false, failure
+	}))
+	_, err := it.Next()
+	assert.Equal(t, failure, err)
+}
+
+func TestErr(t *testing.T) {
+	failure := errors.New("failure")
+	source := FromSlice(ints(0, 3))
+	it := Skip(Limit(Filter(NewDefaultIterator(func() (int, bool, error) {
+		if !source.HasNext() {
+			return 0, false, failure
+		}
+		next, err := source.Next()
+		return next, false, err
+	}), func(item int) (bool, error) {
+		return true, nil
+	}), 10), 1)
+
+	var items []int
+	for it.HasNext() {
+		next, err := it.Next()
+		assert.Nil(t, err)
+		items = append(items, next)
+	}
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, failure, it.Err())
+	assert.Equal(t, iterator.Failed, it.(*DefaultIterator[int]).State())
+}
+
+func TestMerge_Stable(t *testing.T) {
+	type pair struct {
+		key    int
+		source string
+	}
+	compare := func(a, b pair) int {
+		return a.key - b.key
+	}
+	it := Merge(compare,
+		FromSlice([]pair{{1, "a"}, {2, "a"}}),
+		FromSlice([]pair{{1, "b"}, {1, "b"}, {2, "b"}}),
+		FromSlice([]pair{{0, "c"}, {2, "c"}}))
+	assert.Equal(t, []pair{{0, "c"}, {1, "a"}, {1, "b"}, {1, "b"}, {2, "a"}, {2, "b"}, {2, "c"}}, collect(t, it))
+}
+
+func TestBatch(t *testing.T) {
+	it := Batch(FromSlice(ints(0, 7)), 3)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, collect(t, it))
+}
@@ -0,0 +1,276 @@
+package typed
+
+import (
+	"container/heap"
+	"errors"
+
+	iterator "github.com/calvernaz/go-iterators"
+)
+
+// Helper Functions
+
+type PredicateFunc[T any] func(item T) (bool, error)
+
+// Creates a wrapper-iterator over the original that will filter elements according to the filter function specified
+func Filter[T any](iter Iterator[T], test PredicateFunc[T]) Iterator[T] {
+	index := 0
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			for iter.HasNext() {
+				ret, err := iter.Next()
+				if err != nil {
+					return zero, true, err
+				}
+				ok, err := test(ret)
+				if err != nil {
+					return zero, false, &iterator.StageError{Op: "Filter", Index: index, Err: err}
+				}
+				index++
+				if ok {
+					return ret, false, nil
+				}
+			}
+			return zero, true, iter.Err()
+		},
+		closer: iter.Close,
+	}
+}
+
+type TransformFunc[A, B any] func(item A) (B, error)
+
+// Creates a wrapper-iterator over the original that will transform elements of type A into elements of type B
+func Transform[A, B any](iter Iterator[A], fn TransformFunc[A, B]) Iterator[B] {
+	index := 0
+	return &DefaultIterator[B]{
+		ComputeNext: func() (B, bool, error) {
+			var zero B
+			if !iter.HasNext() {
+				return zero, true, iter.Err()
+			}
+			ret, err := iter.Next()
+			if err != nil {
+				return zero, false, err
+			}
+			next, err := fn(ret)
+			if err != nil {
+				return zero, false, &iterator.StageError{Op: "Transform", Index: index, Err: err}
+			}
+			index++
+			return next, false, nil
+		},
+		closer: iter.Close,
+	}
+}
+
+// Creates a wrapper-iterator over the original that will skip the first 'howMany' items
+func Skip[T any](it Iterator[T], howMany int) Iterator[T] {
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			for howMany > 0 {
+				if !it.HasNext() {
+					return zero, true, it.Err()
+				}
+				if _, err := it.Next(); err != nil {
+					return zero, true, err
+				}
+				howMany--
+			}
+
+			if !it.HasNext() {
+				return zero, true, it.Err()
+			}
+			ret, err := it.Next()
+			if err != nil {
+				return zero, true, err
+			}
+			return ret, false, nil
+		},
+		closer: it.Close,
+	}
+}
+
+// Creates a wrapper-iterator over the original that will iterate until there are no more items or the 'upperBound' is reached.
+func Limit[T any](it Iterator[T], upperBound int) Iterator[T] {
+	items := 0
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			if items == upperBound {
+				return zero, true, nil
+			}
+			if !it.HasNext() {
+				return zero, true, it.Err()
+			}
+			ret, err := it.Next()
+			if err != nil {
+				return zero, true, err
+			}
+			items++
+			return ret, false, nil
+		},
+		closer: it.Close,
+	}
+}
+
+// Appends multiple iterators together exposing them as a single virtual iterator.
+func Concat[T any](iterators ...Iterator[T]) Iterator[T] {
+	current := 0
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			for current < len(iterators) {
+				iterator := iterators[current]
+				if !iterator.HasNext() {
+					if err := iterator.Err(); err != nil {
+						return zero, true, err
+					}
+					_ = iterator.Close()
+					current++
+					continue
+				}
+				next, err := iterator.Next()
+				if err != nil {
+					return zero, true, err
+				}
+				return next, false, nil
+			}
+			return zero, true, nil
+		},
+		closer: func() error {
+			return closeAll(iterators)
+		},
+	}
+}
+
+type CompareFunc[T any] func(item1 T, item2 T) int
+
+// Merge combines multiple sorted iterators into a single sorted iterator.
+// The head of every iterator is kept in a min-heap, so each element costs O(log k) comparisons.
+// Equal elements are returned in the order of the iterators they come from.
+func Merge[T any](compareFn CompareFunc[T], iterators ...Iterator[T]) Iterator[T] {
+	var failure error
+	var heads *mergeHeap[T]
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			if heads == nil {
+				heads = &mergeHeap[T]{compareFn: compareFn}
+				for i, it := range iterators {
+					next, ok, err := pull(it)
+					if err != nil {
+						return zero, true, err
+					}
+					if ok {
+						heads.entries = append(heads.entries, mergeEntry[T]{item: next, index: i})
+					}
+				}
+				heap.Init(heads)
+			}
+			if failure != nil {
+				return zero, true, failure
+			}
+			if heads.Len() == 0 {
+				return zero, true, nil
+			}
+
+			// the failure of the iterator is reported after its last element
+			min := heads.entries[0]
+			next, ok, err := pull(iterators[min.index])
+			if err != nil {
+				failure = err
+			} else if ok {
+				heads.entries[0].item = next
+				heap.Fix(heads, 0)
+			} else {
+				heap.Pop(heads)
+			}
+			return min.item, false, nil
+		},
+		closer: func() error {
+			return closeAll(iterators)
+		},
+	}
+}
+
+// EqualsFunc returns true is items are equal
+type EqualsFunc[T any] func(item1 T, item2 T) bool
+
+// Dedup eliminates consecutive duplicates
+func Dedup[T any](it Iterator[T], equalsFn EqualsFunc[T]) Iterator[T] {
+	var prev T
+	first := true
+	return &DefaultIterator[T]{
+		ComputeNext: func() (T, bool, error) {
+			var zero T
+			for it.HasNext() {
+				ret, err := it.Next()
+				if err != nil {
+					return zero, true, err
+				}
+				if first || !equalsFn(prev, ret) {
+					first = false
+					prev = ret
+					return ret, false, nil
+				}
+			}
+			return zero, true, it.Err()
+		},
+		closer: it.Close,
+	}
+}
+
+// The head of one of the merged iterators
+type mergeEntry[T any] struct {
+	item  T
+	index int
+}
+
+// A min-heap of the merged iterators heads, ties are resolved by iterator index
+type mergeHeap[T any] struct {
+	entries   []mergeEntry[T]
+	compareFn CompareFunc[T]
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.entries) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	c := h.compareFn(h.entries[i].item, h.entries[j].item)
+	return c < 0 || (c == 0 && h.entries[i].index < h.entries[j].index)
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.entries = append(h.entries, x.(mergeEntry[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+// pull returns the next element of the iterator and true, or false once the iterator has no more elements.
+// The error stopping the iterator is returned, if any.
+func pull[T any](it Iterator[T]) (T, bool, error) {
+	if !it.HasNext() {
+		var zero T
+		return zero, false, it.Err()
+	}
+	next, err := it.Next()
+	if err != nil {
+		return next, false, err
+	}
+	return next, true, nil
+}
+
+// closeAll closes every iterator, joining the errors encountered.
+func closeAll[T any](iterators []Iterator[T]) error {
+	var errs []error
+	for _, it := range iterators {
+		if err := it.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package typed
+
+import "iter"
+
+// ToSeq exposes the iterator as a range-over-func sequence.
+// An error computing the next element is yielded as the last pair of the loop.
+// The iterator is closed when the loop ends, including when it breaks early.
+func ToSeq[T any](it Iterator[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for it.HasNext() {
+			next, err := it.Next()
+			if !yield(next, err) || err != nil {
+				_ = it.Close()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			_ = it.Close()
+			yield(zero, err)
+			return
+		}
+		if err := it.Close(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// FromSeq returns an iterator over a range-over-func sequence.
+// A non nil error yielded by the sequence fails the iterator, closing the iterator stops the sequence.
+func FromSeq[T any](seq iter.Seq2[T, error]) Iterator[T] {
+	return FromPull(iter.Pull2(seq))
+}
+
+// FromPull returns an iterator over the next and stop functions returned by iter.Pull2.
+// Closing the iterator calls stop.
+func FromPull[T any](next func() (T, error, bool), stop func()) Iterator[T] {
+	return NewCloseableIterator(func() (T, bool, error) {
+		item, err, ok := next()
+		if !ok {
+			return item, true, nil
+		}
+		if err != nil {
+			return item, false, err
+		}
+		return item, false, nil
+	}, func() error {
+		stop()
+		return nil
+	})
+}
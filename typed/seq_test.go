@@ -0,0 +1,73 @@
+package typed
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSeq(t *testing.T) {
+	var items []int
+	for next, err := range ToSeq(FromSlice(ints(0, 5))) {
+		assert.Nil(t, err)
+		items = append(items, next)
+	}
+	assert.Equal(t, ints(0, 5), items)
+}
+
+func TestToSeq_ClosesOnBreak(t *testing.T) {
+	closed := false
+	it := NewCloseableIterator(FromSlice(ints(0, 5)).(*DefaultIterator[int]).ComputeNext, func() error {
+		closed = true
+		return nil
+	})
+	for next := range ToSeq(it) {
+		if next == 2 {
+			break
+		}
+	}
+	assert.True(t, closed)
+}
+
+func TestToSeq_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	it := Transform(FromSlice(ints(0, 5)), func(item int) (int, error) {
+		if item == 1 {
+			return 0, failure
+		}
+		return item, nil
+	})
+
+	var errs []error
+	for _, err := range ToSeq(it) {
+		errs = append(errs, err)
+	}
+	assert.Len(t, errs, 2)
+	assert.Nil(t, errs[0])
+	assert.True(t, errors.Is(errs[1], failure))
+}
+
+func TestFromSeq(t *testing.T) {
+	seq := func(yield func(string, error) bool) {
+		for _, s := range []string{"a", "b"} {
+			if !yield(s, nil) {
+				return
+			}
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, collect(t, FromSeq(seq)))
+}
+
+func TestFromPull(t *testing.T) {
+	next, stop := iter.Pull2(func(yield func(int, error) bool) {
+		for _, v := range slices.Backward(ints(0, 3)) {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	})
+	assert.Equal(t, []int{2, 1, 0}, collect(t, FromPull(next, stop)))
+}
@@ -0,0 +1,87 @@
+package iterator
+
+// Window returns an iterator over windows ([]interface{}) of size consecutive elements, the start of each window
+// being step elements after the start of the previous one: windows slide over the elements when step is lower than
+// size, are tumbling when step equals size. Only full windows are returned.
+// Every window is a newly allocated slice, see WindowReuse to avoid the allocation.
+func Window(it Iterator, size, step int) Iterator {
+	return window(it, size, step, false)
+}
+
+// WindowReuse is like Window but returns the same slice for every window, overwritten when the iteration continues.
+// Copy the window to retain it past the next call to HasNext or Next.
+func WindowReuse(it Iterator, size, step int) Iterator {
+	return window(it, size, step, true)
+}
+
+func window(it Iterator, size, step int, reuse bool) Iterator {
+	if size < 1 || step < 1 {
+		panic("iterator: window size and step must be positive")
+	}
+	var buffer []interface{}
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			// the elements to read to complete the window
+			missing := size
+			if buffer == nil {
+				buffer = make([]interface{}, 0, size)
+			} else if step < size {
+				buffer = buffer[:copy(buffer, buffer[step:])]
+				missing = step
+			} else {
+				buffer = buffer[:0]
+				for skip := step - size; skip > 0; skip-- {
+					_, ok, err := pull(it)
+					if err != nil || !ok {
+						return nil, true, err
+					}
+				}
+			}
+
+			for ; missing > 0; missing-- {
+				next, ok, err := pull(it)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				buffer = append(buffer, next)
+			}
+			if reuse {
+				return buffer, false, nil
+			}
+			return append([]interface{}(nil), buffer...), false, nil
+		},
+		closer: func() error {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
+
+// Pairwise returns an iterator over the pairs of consecutive elements: (e0, e1), (e1, e2), (e2, e3)...
+func Pairwise(it Iterator) Iterator {
+	var prev interface{}
+	started := false
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if !started {
+				first, ok, err := pull(it)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				prev = first
+				started = true
+			}
+			next, ok, err := pull(it)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			pair := Pair{First: prev, Second: next}
+			prev = next
+			return pair, false, nil
+		},
+		closer: func() error {
+			return it.Close()
+		},
+		sources: []Iterator{it},
+	}
+}
@@ -0,0 +1,70 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		step     int
+		expected [][]int
+	}{
+		{"sliding", 3, 1, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6}}},
+		{"sliding by two", 3, 2, [][]int{{0, 1, 2}, {2, 3, 4}, {4, 5, 6}}},
+		{"tumbling", 3, 3, [][]int{{0, 1, 2}, {3, 4, 5}}},
+		{"hopping", 2, 3, [][]int{{0, 1}, {3, 4}}},
+		{"larger than the input", 8, 1, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			iterator := Window(Items(generateItems(0, 7)).Iterator(), test.size, test.step)
+			assert.Equal(t, test.expected, batchIds(t, iterator))
+			assert.Nil(t, iterator.Err())
+			assert.Nil(t, iterator.Close())
+		})
+	}
+}
+
+func TestWindowReuse(t *testing.T) {
+	iterator := WindowReuse(Items(generateItems(0, 5)).Iterator(), 2, 1)
+
+	var first []interface{}
+	var windows [][]int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		window := item.([]interface{})
+		if first == nil {
+			first = window
+		}
+		// the same backing array is reused
+		assert.Equal(t, &first[0], &window[0])
+		windows = append(windows, ids(window...))
+	}
+	assert.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}}, windows)
+}
+
+func TestWindow_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Window(failingIterator(generateItems(0, 4), failure), 2, 1)
+	assert.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}}, batchIds(t, iterator))
+	assert.Equal(t, failure, iterator.Err())
+}
+
+func TestPairwise(t *testing.T) {
+	iterator := Pairwise(Items(generateItems(0, 4)).Iterator())
+	var pairs [][]int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		pair := item.(Pair)
+		pairs = append(pairs, ids(pair.First, pair.Second))
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}}, pairs)
+}
@@ -0,0 +1,93 @@
+package iterator
+
+// Pair holds two elements, e.g. the elements found at the same position of two zipped iterators.
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+// Zip returns an iterator over the pairs formed by the elements found at the same position of both iterators.
+// The iteration ends as soon as either of them does.
+func Zip(a, b Iterator) Iterator {
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			first, ok, err := pull(a)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			second, ok, err := pull(b)
+			if err != nil || !ok {
+				return nil, true, err
+			}
+			return Pair{First: first, Second: second}, false, nil
+		},
+		closer: func() error {
+			return closeAll([]Iterator{a, b})
+		},
+		sources: []Iterator{a, b},
+	}
+}
+
+// ZipLongest is like Zip but continues until both iterators end,
+// filling the pairs with fillA or fillB once the corresponding iterator has no more elements.
+func ZipLongest(a, b Iterator, fillA, fillB interface{}) Iterator {
+	aDone, bDone := false, false
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			first, second := fillA, fillB
+			if !aDone {
+				next, ok, err := pull(a)
+				if err != nil {
+					return nil, true, err
+				}
+				if ok {
+					first = next
+				}
+				aDone = !ok
+			}
+			if !bDone {
+				next, ok, err := pull(b)
+				if err != nil {
+					return nil, true, err
+				}
+				if ok {
+					second = next
+				}
+				bDone = !ok
+			}
+			if aDone && bDone {
+				return nil, true, nil
+			}
+			return Pair{First: first, Second: second}, false, nil
+		},
+		closer: func() error {
+			return closeAll([]Iterator{a, b})
+		},
+		sources: []Iterator{a, b},
+	}
+}
+
+// ZipN returns an iterator over slices holding the elements found at the same position of every iterator,
+// in the order the iterators are given. The iteration ends as soon as any of them does.
+func ZipN(iterators ...Iterator) Iterator {
+	return &DefaultIterator{
+		ComputeNext: func() (interface{}, bool, error) {
+			if len(iterators) == 0 {
+				return nil, true, nil
+			}
+			row := make([]interface{}, len(iterators))
+			for i, it := range iterators {
+				next, ok, err := pull(it)
+				if err != nil || !ok {
+					return nil, true, err
+				}
+				row[i] = next
+			}
+			return row, false, nil
+		},
+		closer: func() error {
+			return closeAll(iterators)
+		},
+		sources: iterators,
+	}
+}
@@ -0,0 +1,92 @@
+package iterator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(items ...interface{}) []int {
+	var result []int
+	for _, item := range items {
+		if item == nil {
+			result = append(result, -1)
+			continue
+		}
+		result = append(result, item.(*Item).ID)
+	}
+	return result
+}
+
+func TestZip(t *testing.T) {
+	closed := 0
+	closer := func() error {
+		closed++
+		return nil
+	}
+	a := NewCloseableIterator(next(generateItems(0, 5)), closer)
+	b := NewCloseableIterator(next(generateItems(10, 13)), closer)
+
+	iterator := Zip(a, b)
+	var firsts, seconds []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		pair := item.(Pair)
+		firsts = append(firsts, ids(pair.First)...)
+		seconds = append(seconds, ids(pair.Second)...)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{0, 1, 2}, firsts)
+	assert.Equal(t, []int{10, 11, 12}, seconds)
+
+	assert.Nil(t, iterator.Close())
+	assert.Equal(t, 2, closed)
+}
+
+func TestZipLongest(t *testing.T) {
+	iterator := ZipLongest(Items(generateItems(0, 2)).Iterator(), Items(generateItems(10, 13)).Iterator(), nil, nil)
+	var firsts, seconds []int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		pair := item.(Pair)
+		firsts = append(firsts, ids(pair.First)...)
+		seconds = append(seconds, ids(pair.Second)...)
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []int{0, 1, -1}, firsts)
+	assert.Equal(t, []int{10, 11, 12}, seconds)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestZipN(t *testing.T) {
+	iterator := ZipN(
+		Items(generateItems(0, 3)).Iterator(),
+		Items(generateItems(10, 14)).Iterator(),
+		Items(generateItems(20, 23)).Iterator())
+
+	var rows [][]int
+	for iterator.HasNext() {
+		item, err := iterator.Next()
+		assert.Nil(t, err)
+		rows = append(rows, ids(item.([]interface{})...))
+	}
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, [][]int{{0, 10, 20}, {1, 11, 21}, {2, 12, 22}}, rows)
+	assert.Nil(t, iterator.Close())
+}
+
+func TestZip_WhenErrorOccurs(t *testing.T) {
+	failure := errors.New("failure")
+	iterator := Zip(Items(generateItems(0, 5)).Iterator(), failingIterator(generateItems(0, 1), failure))
+
+	i := 0
+	for iterator.HasNext() {
+		_, _ = iterator.Next()
+		i++
+	}
+	assert.Equal(t, 1, i)
+	assert.Equal(t, failure, iterator.Err())
+}